@@ -0,0 +1,49 @@
+package digo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AssertImplements panics if *Concrete does not implement Abstract, naming the
+// first missing method. It is meant for package-level declarations, so a
+// binding that could never resolve fails at program start rather than with a
+// TypeMismatchError at resolution time:
+//
+//	var _ = digo.AssertImplements[Database, PostgresDB]()
+//
+// It always returns true.
+func AssertImplements[Abstract Lifecycle, Concrete any]() bool {
+	abstract := reflect.TypeOf((*Abstract)(nil)).Elem()
+	concrete := reflect.TypeOf((*Concrete)(nil))
+	if abstract.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("digo: AssertImplements: %s is not an interface type", abstract))
+	}
+	if concrete.Implements(abstract) {
+		return true
+	}
+	for i := 0; i < abstract.NumMethod(); i++ {
+		method := abstract.Method(i)
+		implemented, ok := concrete.MethodByName(method.Name)
+		if !ok {
+			panic(fmt.Sprintf("digo: %s does not implement %s (missing method %s)", concrete, abstract, method.Name))
+		}
+		if methodSignature(implemented.Type) != method.Type {
+			panic(fmt.Sprintf("digo: %s does not implement %s (wrong type for method %s)", concrete, abstract, method.Name))
+		}
+	}
+	panic(fmt.Sprintf("digo: %s does not implement %s", concrete, abstract))
+}
+
+// methodSignature returns the type of a method expression without its receiver.
+func methodSignature(fn reflect.Type) reflect.Type {
+	in := make([]reflect.Type, 0, fn.NumIn()-1)
+	for i := 1; i < fn.NumIn(); i++ {
+		in = append(in, fn.In(i))
+	}
+	out := make([]reflect.Type, 0, fn.NumOut())
+	for i := 0; i < fn.NumOut(); i++ {
+		out = append(out, fn.Out(i))
+	}
+	return reflect.FuncOf(in, out, fn.IsVariadic())
+}
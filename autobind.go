@@ -0,0 +1,107 @@
+package digo
+
+import (
+	"reflect"
+	"strings"
+)
+
+// AutoBinding is a marker embedded in services registered with AutoBind.
+// Its digo struct tag holds the scope followed by optional name= and tag= options:
+//
+//	type Mailer struct {
+//		digo.AutoBinding `digo:"singleton,name=primary,tag=mail"`
+//	}
+type AutoBinding struct{}
+
+var autoBindingType = reflect.TypeOf(AutoBinding{})
+
+// AutoBind registers each service under its concrete type, with the scope, name
+// and tags declared on its embedded AutoBinding field. Services must be pointers
+// to structs. Either every service is registered or none is.
+// Returns InvalidTagError if a service has no AutoBinding field or its tag is malformed.
+// Returns NilServiceError if a service is nil.
+func AutoBind(services ...Lifecycle) error {
+	type autoBinding struct {
+		service Lifecycle
+		key     bindingKey
+		tags    []string
+	}
+	bindings := make([]autoBinding, 0, len(services))
+	for _, service := range services {
+		if service == nil {
+			return &NilServiceError{Type: "<nil>"}
+		}
+		key, tags, err := parseAutoBinding(reflect.TypeOf(service))
+		if err != nil {
+			return err
+		}
+		bindings = append(bindings, autoBinding{service: service, key: key, tags: tags})
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	definitions := make([]bindingDefinition, len(bindings))
+	for i, b := range bindings {
+		binding, err := instance.newBinding(b.service, b.key.typ, b.key.scope, nil)
+		if err != nil {
+			return err
+		}
+		binding.tags = b.tags
+		definitions[i] = binding
+	}
+	for i, b := range bindings {
+		instance.register(b.key, definitions[i])
+	}
+	return nil
+}
+
+// parseAutoBinding reads the binding key and tags from the AutoBinding field of serviceType.
+func parseAutoBinding(serviceType reflect.Type) (bindingKey, []string, error) {
+	if serviceType.Kind() != reflect.Ptr || serviceType.Elem().Kind() != reflect.Struct {
+		return bindingKey{}, nil, &InvalidTagError{Type: serviceType.String()}
+	}
+
+	field, ok := findAutoBinding(serviceType.Elem())
+	if !ok {
+		return bindingKey{}, nil, &InvalidTagError{Type: serviceType.String()}
+	}
+	tag := field.Tag.Get("digo")
+	parts := strings.Split(tag, ",")
+
+	scope := Scope(strings.TrimSpace(parts[0]))
+	switch scope {
+	case ScopeSingleton, ScopeTransient, ScopeRequest:
+	default:
+		return bindingKey{}, nil, &InvalidTagError{Type: serviceType.String(), Tag: tag}
+	}
+
+	key := makeBindingKey(scope, serviceType)
+	var tags []string
+	for _, option := range parts[1:] {
+		name, value, ok := strings.Cut(strings.TrimSpace(option), "=")
+		if !ok || value == "" {
+			return bindingKey{}, nil, &InvalidTagError{Type: serviceType.String(), Tag: tag}
+		}
+		switch name {
+		case "name":
+			key.name = value
+		case "tag":
+			tags = append(tags, value)
+		default:
+			return bindingKey{}, nil, &InvalidTagError{Type: serviceType.String(), Tag: tag}
+		}
+	}
+	return key, tags, nil
+}
+
+// findAutoBinding returns the AutoBinding field of structType, if it has one.
+func findAutoBinding(structType reflect.Type) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		if field := structType.Field(i); field.Type == autoBindingType {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,39 @@
+package digo
+
+// BaseMergePolicy controls which context wins when a binding context and the
+// container's base context (see SetBaseValue) hold the same key.
+type BaseMergePolicy int
+
+const (
+	// BaseWins lets the container's base values override the binding context.
+	// It is the default, so a value passed at bind time is silently replaced
+	// when the base context carries the same key.
+	BaseWins BaseMergePolicy = iota
+	// BindingWins lets the values of the binding context override the base context.
+	BindingWins
+)
+
+// SetBaseMergePolicy sets which context takes precedence on key collisions when
+// a binding context is merged with the base context. It applies to bindings
+// registered after the call. Reset restores BaseWins.
+func SetBaseMergePolicy(policy BaseMergePolicy) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.baseMergePolicy = policy
+	instance.mu.Unlock()
+}
+
+// withBase merges the base context into a binding context according to the
+// base merge policy. The result keeps the binding context's cancellation.
+// Callers must hold c.mu.
+func (c *container) withBase(ctx *ContainerContext) *ContainerContext {
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	if c.baseMergePolicy == BindingWins {
+		merged := c.ctx.MergeWith(ctx)
+		merged.Context = ctx.Context
+		return merged
+	}
+	return ctx.MergeWith(c.ctx)
+}
@@ -0,0 +1,189 @@
+package digo
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// BindingBuilder collects the options of a binding before it is registered.
+// Create one with Bind and finish it with AsSingleton, AsTransient or AsRequest.
+type BindingBuilder[T Lifecycle] struct {
+	service   T
+	name      string
+	tags      []string
+	ctx       *ContainerContext
+	predicate ContextPredicate
+	typed     func(ctx *ContainerContext) (T, error)
+	timeout   time.Duration
+	priority  int
+}
+
+// Bind starts a fluent binding for service.
+func Bind[T Lifecycle](service T) *BindingBuilder[T] {
+	return &BindingBuilder[T]{service: service}
+}
+
+// Named registers the binding under name, alongside any unnamed binding of the same type.
+// Named bindings are resolved with ResolveNamed.
+func (b *BindingBuilder[T]) Named(name string) *BindingBuilder[T] {
+	b.name = name
+	return b
+}
+
+// Tagged adds tags to the binding. Tagged bindings are resolved with ResolveTagged.
+func (b *BindingBuilder[T]) Tagged(tags ...string) *BindingBuilder[T] {
+	b.tags = append(b.tags, tags...)
+	return b
+}
+
+// WithContext sets the binding context.
+func (b *BindingBuilder[T]) WithContext(ctx *ContainerContext) *BindingBuilder[T] {
+	b.ctx = ctx
+	return b
+}
+
+// WithShutdownTimeout bounds the service's OnShutdown by d. Shutdown abandons a
+// service that overruns it and reports a ShutdownError wrapping
+// context.DeadlineExceeded. OnShutdown receives a context carrying the deadline.
+func (b *BindingBuilder[T]) WithShutdownTimeout(d time.Duration) *BindingBuilder[T] {
+	b.timeout = d
+	return b
+}
+
+// WithBootPriority sets the order in which Boot initializes the service:
+// higher priorities boot first, and services of equal priority boot in order
+// of their type name. The default priority is zero.
+func (b *BindingBuilder[T]) WithBootPriority(priority int) *BindingBuilder[T] {
+	b.priority = priority
+	return b
+}
+
+// When sets the predicate evaluated on resolution.
+// Predicates are not supported for singletons.
+func (b *BindingBuilder[T]) When(predicate ContextPredicate) *BindingBuilder[T] {
+	b.predicate = predicate
+	b.typed = nil
+	return b
+}
+
+// WhenTyped sets a predicate returning T, evaluated on resolution. The compiler
+// checks its return type, and resolution uses its result as T directly.
+// Predicates are not supported for singletons.
+func (b *BindingBuilder[T]) WhenTyped(predicate func(ctx *ContainerContext) (T, error)) *BindingBuilder[T] {
+	b.predicate = nil
+	b.typed = predicate
+	if predicate != nil {
+		b.predicate = erasePredicate(predicate)
+	}
+	return b
+}
+
+// AsSingleton registers the binding with singleton scope.
+// Returns InvalidScopeError if a predicate was set.
+// Returns NilServiceError if the service is nil.
+func (b *BindingBuilder[T]) AsSingleton() error {
+	if b.predicate != nil {
+		return &InvalidScopeError{Type: reflect.TypeOf((*T)(nil)).Elem().String(), Scope: string(ScopeSingleton)}
+	}
+	return b.register(ScopeSingleton)
+}
+
+// AsTransient registers the binding with transient scope.
+// Returns NilServiceError if the service is nil.
+func (b *BindingBuilder[T]) AsTransient() error {
+	return b.register(ScopeTransient)
+}
+
+// AsRequest registers the binding with request scope.
+// Returns NilServiceError if the service is nil.
+func (b *BindingBuilder[T]) AsRequest() error {
+	return b.register(ScopeRequest)
+}
+
+func (b *BindingBuilder[T]) register(scope Scope) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(b.service, serviceType, scope, b.ctx)
+	if err != nil {
+		return err
+	}
+	binding.predicate = b.predicate
+	if b.typed != nil {
+		binding.typedPredicate = b.typed
+	}
+	binding.tags = append([]string(nil), b.tags...)
+	binding.shutdownTimeout = b.timeout
+	binding.priority = b.priority
+
+	key := makeBindingKey(scope, serviceType)
+	key.name = b.name
+	instance.register(key, binding)
+	return nil
+}
+
+// ResolveNamed resolves the binding registered under name with the given scope.
+// Returns BindingNotFoundError if no such binding is registered.
+func ResolveNamed[T Lifecycle](scope Scope, name string) (T, error) {
+	key := makeBindingKey(scope, reflect.TypeOf((*T)(nil)).Elem())
+	key.name = name
+	return wrapResult(resolveKey[T](key))
+}
+
+// ResolveTagged resolves every binding of T carrying tag, in registration order.
+// Returns the first resolution error encountered.
+func ResolveTagged[T Lifecycle](tag string) ([]T, error) {
+	instance := GetContainer()
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+
+	type tagged struct {
+		key bindingKey
+		id  uint64
+	}
+	var matches []tagged
+	instance.mu.RLock()
+	instance.bindings.Range(func(key bindingKey, binding bindingDefinition) bool {
+		if key.typ != serviceType {
+			return true
+		}
+		for _, t := range binding.tags {
+			if t == tag {
+				matches = append(matches, tagged{key: key, id: binding.id})
+				break
+			}
+		}
+		return true
+	})
+	instance.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].id < matches[j].id })
+
+	services := make([]T, 0, len(matches))
+	for _, match := range matches {
+		service, err := resolveKey[T](match.key)
+		if err != nil {
+			return nil, instance.wrapError(err)
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// resolveKey resolves a binding key with the semantics of its scope.
+func resolveKey[T Lifecycle](key bindingKey) (T, error) {
+	switch key.scope {
+	case ScopeSingleton:
+		return resolveSingleton[T](key)
+	case ScopeRequest:
+		return resolveRequest[T](key)
+	case ScopeTransient:
+		return resolveTransient[T](key, false)
+	case ScopeSession:
+		return resolveSingleton[T](key)
+	}
+	var zero T
+	return zero, &InvalidScopeError{Type: key.typ.String(), Scope: string(key.scope)}
+}
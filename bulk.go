@@ -0,0 +1,70 @@
+package digo
+
+import "reflect"
+
+// BindSpec describes one binding for BindAll.
+type BindSpec struct {
+	// Type is the type the service is bound as, usually an interface type.
+	Type reflect.Type
+	// Scope is the scope of the binding.
+	Scope Scope
+	// Instance is the service to bind. It must implement Type.
+	Instance Lifecycle
+	// Name optionally registers the binding under a name, as BindingBuilder.Named does.
+	Name string
+}
+
+// BindByType registers service as serviceType with the given scope and optional
+// name, for wiring where the type is only known at runtime. The binding uses the
+// container's base context.
+// Returns TypeMismatchError if service does not implement serviceType.
+// Returns InvalidScopeError if scope is unknown.
+// Returns NilServiceError if the service is nil.
+func BindByType(serviceType reflect.Type, scope Scope, service Lifecycle, name ...string) error {
+	if serviceType == nil || service == nil {
+		return &NilServiceError{Type: typeName(serviceType)}
+	}
+	switch scope {
+	case ScopeSingleton, ScopeTransient, ScopeRequest:
+	default:
+		return &InvalidScopeError{Type: serviceType.String(), Scope: string(scope)}
+	}
+	if !reflect.TypeOf(service).AssignableTo(serviceType) {
+		return &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(service).String()}
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, scope, nil)
+	if err != nil {
+		return err
+	}
+	key := makeBindingKey(scope, serviceType)
+	if len(name) > 0 {
+		key.name = name[0]
+	}
+	instance.register(key, binding)
+	return nil
+}
+
+// BindAll registers every spec in order with BindByType.
+// It stops at the first failure and returns a BindSpecError carrying the
+// offending index; specs before it remain bound.
+func BindAll(specs []BindSpec) error {
+	for i, spec := range specs {
+		if err := BindByType(spec.Type, spec.Scope, spec.Instance, spec.Name); err != nil {
+			return &BindSpecError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// typeName returns the name of t, or "<nil>" for a nil type.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
@@ -0,0 +1,40 @@
+package digo
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ResolveChain resolves every binding of T, named or not, in registration order.
+// Each link is resolved with the semantics of its scope, so singletons are booted
+// on first use. Returns the first resolution error encountered.
+func ResolveChain[T Lifecycle]() ([]T, error) {
+	instance := GetContainer()
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+
+	type link struct {
+		key bindingKey
+		id  uint64
+	}
+	var links []link
+	instance.mu.RLock()
+	instance.bindings.Range(func(key bindingKey, binding bindingDefinition) bool {
+		if key.typ == serviceType && key.request == "" && !binding.isAlias() {
+			links = append(links, link{key: key, id: binding.id})
+		}
+		return true
+	})
+	instance.mu.RUnlock()
+
+	sort.Slice(links, func(i, j int) bool { return links[i].id < links[j].id })
+
+	chain := make([]T, 0, len(links))
+	for _, l := range links {
+		service, err := resolveKey[T](l.key)
+		if err != nil {
+			return nil, instance.wrapError(err)
+		}
+		chain = append(chain, service)
+	}
+	return chain, nil
+}
@@ -0,0 +1,48 @@
+package digo
+
+import "errors"
+
+// CircularPolicy controls how the container handles a circular dependency.
+type CircularPolicy int
+
+const (
+	// CircularError fails the resolution with CircularDependencyError.
+	CircularError CircularPolicy = iota
+	// CircularAllowPartial returns the instance already being booted further up
+	// the chain, before its OnBoot has completed. It is a migration aid for code
+	// that tolerates half-constructed dependencies. Bindings without a stored
+	// instance (fresh, factory, provided or conditional) still fail.
+	// A lazy proxy is not offered: Go cannot create a type implementing an
+	// arbitrary interface at runtime.
+	CircularAllowPartial
+)
+
+// SetCircularPolicy sets how circular dependencies are handled.
+// Reset restores CircularError.
+func SetCircularPolicy(policy CircularPolicy) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.circularPolicy = policy
+	instance.mu.Unlock()
+}
+
+// partialInstance returns the stored instance of key if err is a circular
+// dependency and the policy allows returning it half-constructed.
+func partialInstance[T Lifecycle](c *container, key bindingKey, err error) (T, bool) {
+	var zero T
+	var circularErr *CircularDependencyError
+	if !errors.As(err, &circularErr) {
+		return zero, false
+	}
+
+	c.mu.RLock()
+	policy := c.circularPolicy
+	binding, ok := c.bindings.Get(key)
+	c.mu.RUnlock()
+	if policy != CircularAllowPartial || !ok || binding.concrete == nil ||
+		binding.fresh || binding.factory != nil || binding.hasCondition() {
+		return zero, false
+	}
+	typed, ok := binding.concrete.(T)
+	return typed, ok
+}
@@ -0,0 +1,42 @@
+package digo
+
+// Import copies every binding of other into c so feature modules can be composed
+// at the application root. Imported bindings keep their context. Bindings other
+// has already initialized stay owned by other: c resolves them through other and
+// never boots or shuts them down, so OnShutdown runs once per instance.
+// Returns DuplicateBindingError, without importing anything, if c already has a
+// binding for any of the keys in other, and BindAfterBootError if c is sealed.
+func (c *container) Import(other *container) error {
+	if other == nil || other == c {
+		return nil
+	}
+
+	other.mu.RLock()
+	imported := other.snapshot()
+	other.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, binding := range imported {
+		if err := c.checkSealed(binding.abstract); err != nil {
+			return err
+		}
+		if _, ok := c.bindings.Get(binding.key); ok {
+			return &DuplicateBindingError{Key: binding.key.String()}
+		}
+	}
+	for _, binding := range imported {
+		binding.id = c.nextID.Add(1)
+		binding.candidates = append([]matchCandidate(nil), binding.candidates...)
+		binding.tags = append([]string(nil), binding.tags...)
+		if binding.initialized {
+			if binding.owner == nil {
+				binding.owner = other
+			}
+			binding.initialized = false
+		}
+		c.register(binding.key, binding)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package digo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// moduleService is a minimal Lifecycle used to populate standalone containers
+type moduleService struct{}
+
+func (m *moduleService) OnBoot(ctx *ContainerContext) error     { return nil }
+func (m *moduleService) OnShutdown(ctx *ContainerContext) error { return nil }
+
+// countingService records how often it is shut down
+type countingService struct{ shutdowns int }
+
+func (m *countingService) OnBoot(ctx *ContainerContext) error { return nil }
+func (m *countingService) OnShutdown(ctx *ContainerContext) error {
+	m.shutdowns++
+	return nil
+}
+
+type ComposeTestSuite struct {
+	suite.Suite
+	root   *container
+	module *container
+}
+
+func (s *ComposeTestSuite) SetupTest() {
+	s.root = newContainer()
+	s.module = newContainer()
+}
+
+func (s *ComposeTestSuite) TestImport() {
+	serviceType := reflect.TypeOf((*Lifecycle)(nil)).Elem()
+	ctx := NewContainerContext(context.Background()).WithValue("module", "billing")
+	s.NoError(s.root.bind(&moduleService{}, serviceType, ScopeSingleton, nil))
+	s.NoError(s.module.bind(&moduleService{}, serviceType, ScopeTransient, ctx))
+
+	s.NoError(s.root.Import(s.module))
+
+	binding, ok := s.root.bindings.Get(makeBindingKey(ScopeTransient, serviceType))
+	s.True(ok)
+	s.Equal("billing", binding.ctx.Value("module"))
+	s.Equal(2, s.root.bindings.Len())
+	s.Equal(1, s.module.bindings.Len(), "The source container should be left untouched")
+}
+
+func (s *ComposeTestSuite) TestImportDuplicate() {
+	serviceType := reflect.TypeOf((*Lifecycle)(nil)).Elem()
+	s.NoError(s.root.bind(&moduleService{}, serviceType, ScopeSingleton, nil))
+	s.NoError(s.module.bind(&moduleService{}, serviceType, ScopeTransient, nil))
+	s.NoError(s.module.bind(&moduleService{}, serviceType, ScopeSingleton, nil))
+
+	err := s.root.Import(s.module)
+	var duplicateErr *DuplicateBindingError
+	s.ErrorAs(err, &duplicateErr)
+	s.Equal(makeBindingKey(ScopeSingleton, serviceType).String(), duplicateErr.Key)
+	s.Equal(1, s.root.bindings.Len(), "A conflicting import should not copy any binding")
+}
+
+func (s *ComposeTestSuite) TestImportInitializedShutsDownOnce() {
+	service := &countingService{}
+	s.NoError(s.module.bind(service, reflect.TypeOf(service), ScopeSingleton, nil))
+	key := makeBindingKey(ScopeSingleton, reflect.TypeOf(service))
+	resolved, _, err := resolveSingletonIn[*countingService](s.module, key)
+	s.NoError(err)
+
+	s.NoError(s.root.Import(s.module))
+	fromRoot, _, err := resolveSingletonIn[*countingService](s.root, key)
+	s.NoError(err)
+	s.Same(resolved, fromRoot, "The importing container should share the source instance")
+
+	s.NoError(s.root.shutdown(true))
+	s.NoError(s.module.shutdown(true))
+	s.Equal(1, resolved.shutdowns, "Only the source container should shut the instance down")
+}
+
+func TestComposeSuite(t *testing.T) {
+	suite.Run(t, new(ComposeTestSuite))
+}
@@ -0,0 +1,151 @@
+package digo
+
+import (
+	"reflect"
+	"sort"
+)
+
+// constructMethod is the method the container calls to inject dependencies.
+const constructMethod = "Construct"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// dependencyScopes is the order in which a Construct parameter's binding is looked up.
+var dependencyScopes = []Scope{ScopeSingleton, ScopeRequest, ScopeTransient}
+
+// constructOf returns the Construct method of service, if it has one.
+// Returns InvalidConstructError if the method is variadic or returns anything
+// other than nothing or a single error.
+func constructOf(service Lifecycle) (reflect.Value, bool, error) {
+	method := reflect.ValueOf(service).MethodByName(constructMethod)
+	if !method.IsValid() {
+		return reflect.Value{}, false, nil
+	}
+	methodType := method.Type()
+	if methodType.IsVariadic() || methodType.NumOut() > 1 ||
+		(methodType.NumOut() == 1 && methodType.Out(0) != errorType) {
+		return reflect.Value{}, false, &InvalidConstructError{Type: reflect.TypeOf(service).String(), Signature: methodType.String()}
+	}
+	return method, true, nil
+}
+
+// construct injects the dependencies of service through its Construct method,
+// if it has one, before OnBoot runs. Each parameter is resolved from the
+// binding of its type, looked up in singleton, request and transient scope in
+// that order. The resolutions join the current resolution chain, so cycles are
+// reported before OnBoot of any service in the cycle runs.
+func (c *container) construct(service Lifecycle) error {
+	method, ok, err := constructOf(service)
+	if !ok {
+		return err
+	}
+	methodType := method.Type()
+	args := make([]reflect.Value, methodType.NumIn())
+	for i := range args {
+		dep, err := c.resolveDependency(methodType.In(i))
+		if err != nil {
+			return err
+		}
+		args[i] = reflect.ValueOf(dep)
+	}
+	out := method.Call(args)
+	if len(out) == 1 && !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+	return nil
+}
+
+// resolveDependency resolves the binding of depType in the first scope it is bound in.
+func (c *container) resolveDependency(depType reflect.Type) (Lifecycle, error) {
+	for _, scope := range dependencyScopes {
+		if c.isBound(depType, scope) {
+			return resolveKey[Lifecycle](makeBindingKey(scope, depType))
+		}
+	}
+	// Falls through to the singleton lookup so the built-in Resolver is found
+	// and a missing binding is reported
+	return resolveKey[Lifecycle](makeBindingKey(ScopeSingleton, depType))
+}
+
+// CheckDependencies walks the Construct parameters of every bound service
+// without booting anything, so a broken dependency graph is found before any
+// OnBoot side effect runs. Provided bindings are skipped because their type is
+// only known once the provider has run.
+// Returns CircularDependencyError naming a type whose dependencies lead back to it.
+// Returns BindingNotFoundError if a Construct parameter has no binding.
+// Returns InvalidConstructError if a Construct method has an unsupported signature.
+func CheckDependencies() error {
+	instance := GetContainer()
+	instance.mu.RLock()
+	bindings := instance.snapshot()
+	instance.mu.RUnlock()
+
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].id < bindings[j].id })
+	services := make(map[reflect.Type]Lifecycle)
+	for _, binding := range bindings {
+		if binding.concrete == nil || binding.key.name != "" {
+			continue
+		}
+		if _, ok := services[binding.key.typ]; !ok {
+			services[binding.key.typ] = binding.concrete
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[reflect.Type]int)
+	var visit func(t reflect.Type) error
+	visit = func(t reflect.Type) error {
+		switch state[t] {
+		case visiting:
+			return &CircularDependencyError{Type: t.String()}
+		case visited:
+			return nil
+		}
+		service, ok := services[t]
+		if !ok {
+			// Types bound only to a provider cannot be inspected yet
+			if t == resolverType || instance.boundInAnyScope(t) {
+				state[t] = visited
+				return nil
+			}
+			return &BindingNotFoundError{Type: t.String()}
+		}
+		method, ok, err := constructOf(service)
+		if err != nil {
+			return err
+		}
+		state[t] = visiting
+		if ok {
+			for i := 0; i < method.Type().NumIn(); i++ {
+				if err := visit(method.Type().In(i)); err != nil {
+					return err
+				}
+			}
+		}
+		state[t] = visited
+		return nil
+	}
+
+	for _, binding := range bindings {
+		if binding.concrete == nil || binding.key.name != "" {
+			continue
+		}
+		if err := visit(binding.key.typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boundInAnyScope reports whether t has a default binding in any scope.
+func (c *container) boundInAnyScope(t reflect.Type) bool {
+	for _, scope := range dependencyScopes {
+		if c.isBound(t, scope) {
+			return true
+		}
+	}
+	return false
+}
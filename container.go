@@ -2,10 +2,14 @@ package digo
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Package digo provides a high-performance dependency injection container.
@@ -16,21 +20,86 @@ type bindingDefinition struct {
 	scope       Scope
 	concrete    Lifecycle
 	abstract    reflect.Type
+	key         bindingKey
+	id          uint64
 	initialized bool
 	ctx         *ContainerContext
 	predicate   ContextPredicate
+	candidates  []matchCandidate
+	fresh       bool
+	tags        []string
+	provider    serviceProvider
+	factory     transientFactory
+	// shutdownTimeout bounds OnShutdown; zero means no limit
+	shutdownTimeout time.Duration
+	// priority orders the boot pass; higher priorities boot first
+	priority int
+	// aliasOf is the key of the binding this one resolves to, if it is an alias
+	aliasOf bindingKey
+	// interfaceType reports whether abstract is an interface type. Otherwise
+	// the binding is keyed by the service's own concrete type, which may be a
+	// struct value rather than a pointer.
+	interfaceType bool
+	// typed caches concrete as a *T for the abstract type T, so resolving an
+	// initialized singleton skips the interface assertion. It is nil when
+	// concrete is not assignable to abstract.
+	typed any
+	// typedPredicate is the func(*ContainerContext) (T, error) a binding was
+	// given through a typed entry point; predicate holds its erased form
+	typedPredicate any
+	// owner is the container that booted the instance of an imported binding;
+	// resolutions are delegated to it so only the owner shuts the instance down
+	owner *container
+	// stopRelease unregisters the callback releasing a request binding when its
+	// context is cancelled; nil if none was registered
+	stopRelease func() bool
+}
+
+// stopReleasing unregisters the release callback of a binding being removed.
+func (b bindingDefinition) stopReleasing() {
+	if b.stopRelease != nil {
+		b.stopRelease()
+	}
+}
+
+// setConcrete replaces the binding's concrete service and its typed cache.
+func (b *bindingDefinition) setConcrete(service Lifecycle) {
+	b.concrete = service
+	b.typed = nil
+	if service == nil || b.abstract == nil {
+		return
+	}
+	value := reflect.ValueOf(service)
+	if !value.Type().AssignableTo(b.abstract) {
+		return
+	}
+	typed := reflect.New(b.abstract)
+	typed.Elem().Set(value)
+	b.typed = typed.Interface()
+}
+
+// isAlias reports whether the binding resolves to another binding's instance
+// and so never runs a lifecycle of its own.
+func (b bindingDefinition) isAlias() bool {
+	return b.aliasOf.typ != nil
 }
 
 type resolutionState struct {
-	chain    map[string]bool
-	mu       sync.Mutex
-	keyCache []string
+	chain     map[bindingKey]bool
+	mu        sync.Mutex
+	keyCache  []bindingKey
+	holds     int
+	fresh     int
+	refreshed map[bindingKey]bool
+	deadline  context.Context
+	contexts  map[bindingKey]*ContainerContext
+	started   map[bindingKey]resolveStart
 }
 
 // container manages service bindings and their lifecycle.
 // It provides thread-safe access to digo and handles dependency resolution.
 type container struct {
-	bindings        map[string]bindingDefinition
+	bindings        bindingStore
 	ctx             *ContainerContext
 	mu              sync.RWMutex
 	booted          bool
@@ -39,44 +108,125 @@ type container struct {
 	resolutionMu    sync.RWMutex
 	statePool       sync.Pool
 	goidCache       sync.Map
+	goroutines      goroutineIdentifier
+	initLocks       sync.Map
+	nextID          atomic.Uint64
+	events          eventLog
+	historyLimit    atomic.Int64
+	histories       sync.Map
+	latestRequest   map[bindingKey]latestBinding
+	logger          Logger
+	leakDetection   bool
+	freshPasses     atomic.Int32
+	interceptor     atomic.Pointer[ResolveInterceptor]
+	errorWrapper    atomic.Pointer[func(error) error]
+	closing         atomic.Bool
+	redacted        atomic.Pointer[map[interface{}]bool]
+	circularPolicy  CircularPolicy
+	transientPolicy TransientStrategy
+	baseMergePolicy BaseMergePolicy
+	shutdownHooks   []func(ctx *ContainerContext) error
+	sealAfterBoot   bool
+	frozen          bool
+	finalizerGuard  atomic.Bool
+	guarded         sync.Map
+	slowThreshold   atomic.Int64
+	slowStop        chan struct{}
+	resolveTimeout  atomic.Int64
+	beforeBoot      func()
+	afterBoot       func(err error)
+	requests        sync.Map
+	activeRequests  atomic.Int32
+	endedRequests   endedRequests
+	parent          *container
+	rebootMu        sync.Mutex
+	rebootDone      *sync.Cond
+	rebooting       map[bindingKey]bool
+	reboots         atomic.Int32
+	predicateCache  sync.Map
+	waitersMu       sync.Mutex
+	bindWaiters     map[bindingKey]chan struct{}
+	observers       observerSet
+	stats           statCounters
+	dependsOn       map[reflect.Type][]reflect.Type
 }
 
 var (
-	once             sync.Once
-	defaultContainer *container
-	typeStringCache  sync.Map
+	once            sync.Once
+	activeContainer atomic.Pointer[container]
+	typeStringCache sync.Map
 )
 
-func makeBindingKey(scope Scope, serviceType reflect.Type) string {
-	if cached, ok := typeStringCache.Load(serviceType); ok {
-		return string(scope) + ":" + cached.(string)
+// bindingKey identifies a binding by its scope, the identity of its service type,
+// an optional name and, for request and session scope, the request or session it
+// belongs to. Keying by
+// reflect.Type rather than its string form keeps types that stringify
+// identically (same name in different packages, generic instantiations) distinct.
+type bindingKey struct {
+	scope   Scope
+	typ     reflect.Type
+	name    string
+	request string
+}
+
+func makeBindingKey(scope Scope, serviceType reflect.Type) bindingKey {
+	return bindingKey{scope: scope, typ: serviceType}
+}
+
+// String renders the key for error messages and diagnostics.
+func (k bindingKey) String() string {
+	var typeStr string
+	if cached, ok := typeStringCache.Load(k.typ); ok {
+		typeStr = cached.(string)
+	} else {
+		typeStr = k.typ.String()
+		typeStringCache.Store(k.typ, typeStr)
+	}
+	str := string(k.scope) + ":" + typeStr
+	if k.name != "" {
+		str += "#" + k.name
+	}
+	if k.request != "" {
+		str += "@" + k.request
 	}
-	typeStr := serviceType.String()
-	typeStringCache.Store(serviceType, typeStr)
-	return string(scope) + ":" + typeStr
+	return str
 }
 
-// GetContainer returns the singleton container instance.
+// GetContainer returns the container used by the package-level API.
 // The container is initialized on first access with default configuration.
 func GetContainer() *container {
 	once.Do(func() {
-		defaultContainer = &container{
-			bindings:        make(map[string]bindingDefinition, 32),
-			ctx:             NewContainerContext(context.Background()),
-			resolutionState: sync.Map{},
-			statePool: sync.Pool{
-				New: func() interface{} {
-					return &resolutionState{
-						chain:    make(map[string]bool, 8),
-						mu:       sync.Mutex{},
-						keyCache: make([]string, 0, 8),
-					}
-				},
-			},
-			goidCache: sync.Map{},
-		}
+		activeContainer.CompareAndSwap(nil, newContainer())
 	})
-	return defaultContainer
+	if standingIn.Load() > 0 {
+		if owner, ok := standIns.Load(goid()); ok {
+			return owner.(*container)
+		}
+	}
+	return activeContainer.Load()
+}
+
+// newContainer creates an empty container with default configuration.
+func newContainer() *container {
+	c := &container{
+		bindings:        newShardedStore(defaultShards),
+		ctx:             NewContainerContext(context.Background()),
+		resolutionState: sync.Map{},
+		statePool: sync.Pool{
+			New: func() interface{} {
+				return &resolutionState{
+					chain:    make(map[bindingKey]bool, 8),
+					mu:       sync.Mutex{},
+					keyCache: make([]bindingKey, 0, 8),
+				}
+			},
+		},
+		goidCache:     sync.Map{},
+		goroutines:    runtimeGoroutines{},
+		latestRequest: make(map[bindingKey]latestBinding),
+	}
+	c.rebootDone = sync.NewCond(&c.rebootMu)
+	return c
 }
 
 // Boot initializes all singleton digo in the container.
@@ -84,87 +234,431 @@ func GetContainer() *container {
 // Returns an error if any service fails to initialize.
 func Boot() error {
 	instance := GetContainer()
+	return instance.wrapError(instance.boot(false, nil))
+}
+
+// BootAll initializes all singleton digo in the container like Boot, but keeps
+// going after a failure so every misconfigured service is reported at once.
+// Services that fail stay uninitialized.
+// Returns MultiBootError listing an InitializationError per failed service.
+func BootAll() error {
+	instance := GetContainer()
+	return instance.wrapError(instance.boot(true, nil))
+}
+
+// BootAndReport boots every singleton like BootAll and reports the outcome per
+// singleton, keyed by its bound type followed by "#name" for a named binding. A
+// nil entry means the service is booted. Singletons left uninitialized by an
+// earlier boot pass are booted now.
+// Returns MultiBootError listing an InitializationError per failed service.
+func BootAndReport() (map[string]error, error) {
+	instance := GetContainer()
+	failures := make(map[bindingKey]error)
+	instance.boot(true, func(key bindingKey, err error) { failures[key] = err })
+
+	instance.mu.RLock()
+	bindings := instance.snapshot()
+	instance.mu.RUnlock()
+	sortForBoot(bindings)
+
+	report := make(map[string]error)
+	var errs []error
+	for _, binding := range bindings {
+		if binding.scope != ScopeSingleton || binding.isAlias() {
+			continue
+		}
+		err, ok := failures[binding.key]
+		if !ok && !binding.initialized {
+			if _, err = resolveKey[Lifecycle](binding.key); err != nil {
+				var initErr *InitializationError
+				if !errors.As(err, &initErr) {
+					err = &InitializationError{Type: binding.abstract.String(), Err: err}
+				}
+			}
+		}
+		report[singletonName(binding.key)] = err
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return report, instance.wrapError(&MultiBootError{Errors: errs})
+	}
+	return report, nil
+}
+
+// boot runs the boot pass shared by Boot and BootAll. Unless collect is set, it
+// stops at the first failure and returns it. If collecting, failed is called
+// with the key of every service that fails, unless failed is nil.
+func (c *container) boot(collect bool, failed func(key bindingKey, err error)) error {
+	instance := c
 	var bootErr error
+	var failures []error
+	fail := func(binding bindingDefinition, err error) bool {
+		if !collect {
+			bootErr = err
+			return true
+		}
+		var initErr *InitializationError
+		if !errors.As(err, &initErr) {
+			err = &InitializationError{Type: binding.abstract.String(), Err: err}
+		}
+		failures = append(failures, err)
+		if failed != nil {
+			failed(binding.key, err)
+		}
+		return false
+	}
 
+	var result error
 	instance.bootOnce.Do(func() {
-		instance.mu.Lock()
-		if instance.booted {
+		result = instance.runBootPass(func() error {
+			instance.mu.Lock()
+			if instance.booted {
+				instance.mu.Unlock()
+				return nil
+			}
+
+			// Mark container as booted first
+			instance.booted = true
+
+			// Services with a Construct method resolve their dependencies, which
+			// needs the container lock, so they are booted once it is released
+			var constructed []bindingDefinition
+			bindings := instance.snapshot()
+			sortForBoot(bindings)
+			for _, binding := range bindings {
+				key := binding.key
+				if binding.owner != nil {
+					continue
+				}
+				if !binding.initialized && binding.scope == ScopeSingleton && !binding.isAlias() {
+					if binding.concrete == nil {
+						var err error
+						if binding, err = binding.materialize(); err != nil {
+							if fail(binding, &InitializationError{Type: binding.abstract.String(), Err: err}) {
+								break
+							}
+							continue
+						}
+						instance.bindings.Set(key, binding)
+					}
+					if _, ok, _ := constructOf(binding.concrete); ok {
+						constructed = append(constructed, binding)
+						continue
+					}
+					if err := instance.bootService(key, binding.concrete, binding.ctx); err != nil {
+						if fail(binding, err) {
+							break
+						}
+						continue
+					}
+					// Update the binding in the map after initialization
+					binding.initialized = true
+					instance.bindings.Set(key, binding)
+				}
+				if binding.scope == ScopeRequest && binding.key.request != "" {
+					if _, ok, _ := constructOf(binding.concrete); ok {
+						constructed = append(constructed, binding)
+						continue
+					}
+					err := instance.bootService(key, binding.concrete, binding.ctx)
+					if err != nil {
+						if fail(binding, err) {
+							break
+						}
+						continue
+					}
+					binding.initialized = true
+					instance.bindings.Set(key, binding)
+				}
+			}
 			instance.mu.Unlock()
-			return
+
+			if bootErr != nil {
+				return bootErr
+			}
+			for _, binding := range constructed {
+				if _, err := resolveKey[Lifecycle](binding.key); err != nil && fail(binding, err) {
+					break
+				}
+			}
+
+			if len(failures) > 0 {
+				return &MultiBootError{Errors: failures}
+			}
+			return bootErr
+		})
+	})
+	return result
+}
+
+// sortForBoot orders bindings for a boot pass: by descending boot priority,
+// then by key, so startup does not depend on the store's iteration order.
+func sortForBoot(bindings []bindingDefinition) {
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].priority != bindings[j].priority {
+			return bindings[i].priority > bindings[j].priority
 		}
+		return bindings[i].key.String() < bindings[j].key.String()
+	})
+}
 
-		// Mark container as booted first
-		instance.booted = true
+// BootAsync initializes all singleton digo in the container in parallel.
+// It is equivalent to BootAsyncN(0).
+func BootAsync() error {
+	return BootAsyncN(0)
+}
 
-		for key, binding := range instance.bindings {
-			if !binding.initialized && binding.scope == ScopeSingleton {
-				if err := binding.concrete.OnBoot(binding.ctx); err != nil {
-					bootErr = err
-					break
+// BootAsyncN initializes all singleton digo in the container in parallel,
+// running at most maxParallel OnBoot calls at once. A maxParallel of zero or
+// less means no limit. Like Boot it runs at most once until the container is reset.
+// Returns the first error reported by a failing service.
+func BootAsyncN(maxParallel int) error {
+	instance := GetContainer()
+	var bootErr error
+
+	instance.bootOnce.Do(func() {
+		bootErr = instance.runBootPass(func() error {
+			instance.mu.Lock()
+			if instance.booted {
+				instance.mu.Unlock()
+				return nil
+			}
+			instance.booted = true
+
+			var pending []bindingDefinition
+			for _, binding := range instance.snapshot() {
+				if binding.owner != nil {
+					continue
+				}
+				if (binding.scope == ScopeSingleton && !binding.initialized && !binding.isAlias()) || (binding.scope == ScopeRequest && binding.key.request != "") {
+					pending = append(pending, binding)
 				}
-				// Update the binding in the map after initialization
-				binding.initialized = true
-				instance.bindings[key] = binding
 			}
-			if binding.scope == ScopeRequest {
-				err := binding.concrete.OnBoot(binding.ctx)
-				if err != nil {
-					bootErr = err
-					break
+			instance.mu.Unlock()
+			sortForBoot(pending)
+
+			var (
+				wg   sync.WaitGroup
+				once sync.Once
+				sem  chan struct{}
+			)
+			if maxParallel > 0 {
+				sem = make(chan struct{}, maxParallel)
+			}
+
+			// Slots are taken in boot order, so services start in that order
+			for _, binding := range pending {
+				if sem != nil {
+					sem <- struct{}{}
 				}
-				binding.initialized = true
-				instance.bindings[key] = binding
+				wg.Add(1)
+				go func(key bindingKey, binding bindingDefinition) {
+					defer wg.Done()
+					if sem != nil {
+						defer func() { <-sem }()
+					}
+
+					lock := instance.initLock(key)
+					lock.Lock()
+					defer lock.Unlock()
+
+					if binding.scope == ScopeSingleton {
+						current, ok := instance.getBinding(key)
+						if !ok || current.id != binding.id || current.initialized {
+							return
+						}
+						if binding = current; binding.concrete == nil {
+							var err error
+							if binding, err = binding.materialize(); err != nil {
+								once.Do(func() { bootErr = &InitializationError{Type: binding.abstract.String(), Err: err} })
+								return
+							}
+							instance.storeBinding(key, binding)
+						}
+					}
+
+					if err := instance.bootService(key, binding.concrete, binding.ctx); err != nil {
+						once.Do(func() { bootErr = err })
+						return
+					}
+					binding.initialized = true
+					instance.storeBinding(key, binding)
+				}(binding.key, binding)
 			}
-		}
-		instance.mu.Unlock()
+			wg.Wait()
+			return bootErr
+		})
 	})
 
-	return bootErr
+	return instance.wrapError(bootErr)
+}
+
+// SetBeforeBoot installs fn to run once before each boot pass of Boot,
+// BootAll or BootAsyncN, e.g. to start a startup trace span. A call that finds
+// the container already booted runs no hooks. Passing nil removes it.
+func SetBeforeBoot(fn func()) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.beforeBoot = fn
+	instance.mu.Unlock()
+}
+
+// SetAfterBoot installs fn to run once after each boot pass, with the error the
+// pass returns. Passing nil removes it.
+func SetAfterBoot(fn func(err error)) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.afterBoot = fn
+	instance.mu.Unlock()
+}
+
+// runBootPass runs pass between the before and after boot hooks, unless the
+// container is already booted. The hooks run without the container lock held.
+func (c *container) runBootPass(pass func() error) error {
+	c.mu.RLock()
+	booted, before, after := c.booted, c.beforeBoot, c.afterBoot
+	c.mu.RUnlock()
+	if booted {
+		return nil
+	}
+	if before != nil {
+		before()
+	}
+	err := pass()
+	if after != nil {
+		after(err)
+	}
+	return err
+}
+
+// SetSealAfterBoot enables or disables sealing. While enabled, every bind after
+// Boot has run fails with BindAfterBootError, enforcing a configure-then-run lifecycle.
+func SetSealAfterBoot(enabled bool) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.sealAfterBoot = enabled
+	instance.mu.Unlock()
+}
+
+// Freeze rejects every change to the bindings until Unfreeze: binds return
+// ContainerFrozenError, ResetScope fails and Reset is ignored. Resolutions,
+// Boot and Shutdown proceed normally. Unlike SetSealAfterBoot, a freeze is
+// meant to be lifted for controlled reconfiguration.
+func Freeze() {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.frozen = true
+	instance.mu.Unlock()
+}
+
+// Unfreeze lifts a Freeze.
+func Unfreeze() {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.frozen = false
+	instance.mu.Unlock()
+}
+
+// checkSealed rejects a new binding while the container is frozen, or once it
+// is booted and sealed.
+// Callers must hold c.mu.
+func (c *container) checkSealed(serviceType reflect.Type) error {
+	if c.frozen {
+		return &ContainerFrozenError{Op: "bind " + serviceType.String()}
+	}
+	if c.sealAfterBoot && c.booted {
+		return &BindAfterBootError{Type: serviceType.String()}
+	}
+	return nil
+}
+
+// BootOne initializes the singleton bound for T if it is not initialized yet,
+// independently of Boot. It lets a service such as a logger start before the rest;
+// a later Boot skips it.
+// Returns BindingNotFoundError if service is not registered.
+func BootOne[T Lifecycle]() error {
+	_, err := resolveSingleton[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem()))
+	return GetContainer().wrapError(err)
 }
 
-// Shutdown gracefully shuts down digo in the container.
-// If clearSingletons is true, it also removes singleton digo from the container.
-// Returns an error if any service fails to shut down properly.
+// Shutdown gracefully shuts down digo in the container, in reverse registration order.
+// If clearSingletons is true, it also shuts down and removes singleton and session digo.
+// Only initialized services receive OnShutdown, so a service that was never
+// booted, or was already shut down, is not shut down again.
+// Hooks registered with OnShutdownHook run after the services.
+// Returns an error if any service or hook fails to shut down properly.
 func Shutdown(clearSingletons bool) error {
 	instance := GetContainer()
+	return instance.wrapError(instance.shutdown(clearSingletons))
+}
+
+// outlivesRequests reports whether bindings of scope survive Shutdown(false).
+func outlivesRequests(scope Scope) bool {
+	return scope == ScopeSingleton || scope == ScopeSession
+}
+
+// shutdown shuts services down in reverse registration order and removes their bindings.
+func (c *container) shutdown(clearSingletons bool) error {
+	instance := c
+	var leaks []string
+	defer func() { instance.reportLeaks(leaks) }()
 	instance.mu.Lock()
 	defer instance.mu.Unlock()
 
 	// First collect all digo to shutdown
 	toShutdown := make([]bindingDefinition, 0)
 
-	for _, binding := range instance.bindings {
-		if binding.scope != ScopeSingleton || clearSingletons {
+	for _, binding := range instance.snapshot() {
+		if !binding.initialized {
+			// Services that were never booted, or already shut down, are skipped so
+			// OnShutdown runs at most once per boot
+			continue
+		}
+		if !outlivesRequests(binding.scope) || clearSingletons {
 			toShutdown = append(toShutdown, binding)
 		}
 	}
+	sort.Slice(toShutdown, func(i, j int) bool { return toShutdown[i].id > toShutdown[j].id })
 
 	// Shutdown digo
-	for _, binding := range toShutdown {
-		if err := binding.concrete.OnShutdown(binding.ctx); err != nil {
+	for i, binding := range toShutdown {
+		if err := instance.shutdownBinding(binding.key, binding); err != nil {
+			leaks = instance.collectLeaks(toShutdown[i+1:])
 			return &ShutdownError{
 				Type: reflect.TypeOf(binding.concrete).String(),
 				Err:  err,
 			}
 		}
+		// Remember the shutdown in case a later service fails and Shutdown is retried
+		binding.initialized = false
+		instance.bindings.Set(binding.key, binding)
+	}
+	if err := instance.runShutdownHooks(); err != nil {
+		return err
 	}
 
 	// Clear bindings under lock
 	if clearSingletons {
 		instance.resolutionMu.Lock()
-		instance.bindings = make(map[string]bindingDefinition)
+		instance.clearBindings()
+		instance.latestRequest = make(map[bindingKey]latestBinding)
 		instance.booted = false
 		instance.bootOnce = sync.Once{}
-		instance.resolutionState = sync.Map{}
+		instance.clearResolutionStates()
 		instance.resolutionMu.Unlock()
 	} else {
-		// Only remove non-singleton bindings
-		for key, binding := range instance.bindings {
-			if binding.scope != ScopeSingleton {
-				delete(instance.bindings, key)
+		// Only remove request and transient bindings
+		for _, binding := range instance.snapshot() {
+			if !outlivesRequests(binding.scope) {
+				instance.bindings.Delete(binding.key)
+				binding.stopReleasing()
 			}
 		}
+		instance.latestRequest = make(map[bindingKey]latestBinding)
 	}
+	instance.clearRequests()
 
 	return nil
 }
@@ -179,12 +673,43 @@ func BindTransient[T Lifecycle](service T, ctx *ContainerContext, predicate ...C
 
 // BindRequest registers a service with request scope.
 // Service instance is shared within a single request context.
+// Bindings are stored per request_id, so binding the same type for another
+// request does not replace the instance of the first. A binding whose context has
+// no request_id is a template copied for each request begun with BeginRequest.
+// When the binding context is cancelled the instance is shut down and evicted.
+// Returns InvalidContextValueError if the request_id is not a string.
 // Returns NilServiceError if the service is nil.
 func BindRequest[T Lifecycle](service T, ctx *ContainerContext, predicate ...ContextPredicate) error {
 	serviceType := reflect.TypeOf((*T)(nil)).Elem()
 	return GetContainer().bind(service, serviceType, ScopeRequest, ctx, predicate...)
 }
 
+// BindRequestStrict registers a service with request scope like BindRequest,
+// but rejects the binding immediately when its context carries no request_id.
+// Returns MissingContextValueError if request_id is not in the binding context.
+// Returns InvalidContextValueError if the request_id is not a string.
+// Returns NilServiceError if the service is nil.
+func BindRequestStrict[T Lifecycle](service T, ctx *ContainerContext, predicate ...ContextPredicate) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, ScopeRequest, ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkRequestID(binding.ctx); err != nil {
+		return err
+	}
+	if len(predicate) > 0 {
+		binding.predicate = predicate[0]
+	}
+
+	instance.register(makeBindingKey(ScopeRequest, serviceType), binding)
+	return nil
+}
+
 // BindSingleton registers a service with singleton scope.
 // Service instance is shared across the entire application.
 // Returns NilServiceError if the service is nil.
@@ -197,64 +722,194 @@ func BindSingleton[T Lifecycle](service T, ctx ...*ContainerContext) error {
 	return GetContainer().bind(service, serviceType, ScopeSingleton, bindingCtx)
 }
 
+// BindSingletonInitialized registers an already-initialized singleton.
+// OnBoot is never called for the instance, but OnShutdown still runs on shutdown.
+// Returns NilServiceError if the service is nil.
+func BindSingletonInitialized[T Lifecycle](service T, ctx ...*ContainerContext) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	var bindingCtx *ContainerContext
+	if len(ctx) > 0 && ctx[0] != nil {
+		bindingCtx = ctx[0]
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, ScopeSingleton, bindingCtx)
+	if err != nil {
+		return err
+	}
+	binding.initialized = true
+	instance.register(makeBindingKey(ScopeSingleton, serviceType), binding)
+	return nil
+}
+
+// BindSingletonAlsoConcrete registers service as the singleton for T and also
+// for its concrete type, so ResolveSingleton[Database] and
+// ResolveSingleton[*PostgresDB] return the same instance. The concrete binding
+// is an alias of the binding of T: OnBoot and OnShutdown run once, for T.
+// Returns NilServiceError if the service is nil.
+func BindSingletonAlsoConcrete[T Lifecycle](service T, ctx ...*ContainerContext) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	var bindingCtx *ContainerContext
+	if len(ctx) > 0 && ctx[0] != nil {
+		bindingCtx = ctx[0]
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, ScopeSingleton, bindingCtx)
+	if err != nil {
+		return err
+	}
+	key := makeBindingKey(ScopeSingleton, serviceType)
+	instance.register(key, binding)
+
+	if !binding.interfaceType {
+		return nil
+	}
+	concreteType := reflect.TypeOf(service)
+	alias, err := instance.newBinding(service, concreteType, ScopeSingleton, bindingCtx)
+	if err != nil {
+		return err
+	}
+	alias.aliasOf = key
+	instance.register(makeBindingKey(ScopeSingleton, concreteType), alias)
+	return nil
+}
+
 // ResolveTransient resolves a service with transient scope.
 // Returns a new instance on each resolution.
 // Returns BindingNotFoundError if service is not registered.
 // Returns InitializationError if service fails to initialize.
 func ResolveTransient[T Lifecycle]() (T, error) {
+	return wrapResult(resolveTransient[T](makeBindingKey(ScopeTransient, reflect.TypeOf((*T)(nil)).Elem()), false))
+}
+
+// ResolveTransientInheriting resolves a service with transient scope, booting it
+// with its binding context merged with the context of the service resolving it.
+// Values from the resolving service override the binding's own values, so
+// request-scoped values flow down the dependency chain.
+// Outside of a resolution it behaves like ResolveTransient.
+func ResolveTransientInheriting[T Lifecycle]() (T, error) {
+	return wrapResult(resolveTransient[T](makeBindingKey(ScopeTransient, reflect.TypeOf((*T)(nil)).Elem()), true))
+}
+
+func resolveTransient[T Lifecycle](key bindingKey, inherit bool) (T, error) {
 	instance := GetContainer()
+	return boundedByDefault(instance, key, func() (T, error) {
+		return resolveTransientIn[T](instance, key, inherit)
+	})
+}
+
+func resolveTransientIn[T Lifecycle](instance *container, key bindingKey, inherit bool) (_ T, err error) {
+	defer func() { instance.recordEvent(EventResolve, key, err) }()
 	var zero T
-	serviceType := reflect.TypeOf((*T)(nil)).Elem()
-	key := makeBindingKey(ScopeTransient, serviceType)
+	serviceType := key.typ
+
+	if typed, ok, err := intercept[T](instance, key); ok {
+		return typed, err
+	}
 
 	if err := instance.startResolving(key); err != nil {
+		if partial, ok := partialInstance[T](instance, key, err); ok {
+			return partial, nil
+		}
 		return zero, err
 	}
 	defer instance.finishResolving(key)
 
 	instance.mu.Lock()
-	binding, ok := instance.bindings[key]
+	binding, ok := instance.bindings.Get(key)
 	if !ok {
 		instance.mu.Unlock()
-		return zero, &BindingNotFoundError{Type: serviceType.String()}
+		if owner := instance.parentOwner(key); owner != nil {
+			var service T
+			var err error
+			owner.standIn(func() { service, err = resolveTransientIn[T](owner, key, inherit) })
+			return service, err
+		}
+		return zero, instance.missingBinding(key)
+	}
+	if binding.owner != nil {
+		instance.mu.Unlock()
+		return resolveTransientIn[T](binding.owner, key, inherit)
+	}
+
+	bootCtx := binding.ctx
+	if inherit {
+		bootCtx = instance.inheritContext(bootCtx)
+	}
+
+	// Factory bindings construct a new instance on every resolution
+	if binding.factory != nil {
+		instance.mu.Unlock()
+		return constructTransient[T](instance, key, binding, bootCtx, nil)
+	}
+
+	switch instance.transientPolicy {
+	case NewViaFactory:
+		instance.mu.Unlock()
+		return zero, &FactoryRequiredError{Type: serviceType.String()}
+	case NewViaReflect:
+		if !binding.fresh {
+			instance.mu.Unlock()
+			return allocateTransient[T](instance, key, binding, bootCtx)
+		}
+	}
+
+	// Fresh bindings boot a copy of the prototype and never touch the stored instance
+	if binding.fresh && !binding.hasCondition() {
+		instance.mu.Unlock()
+		typed, ok := cloneService(binding.concrete).(T)
+		if !ok {
+			return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+		}
+		if err := instance.bootService(key, typed, instance.bootContext(key, bootCtx)); err != nil {
+			return zero, instance.initializationError(serviceType, err)
+		}
+		return typed, nil
 	}
 
 	// For transient scope, we need to shutdown before reuse
 	if binding.initialized {
-		if err := binding.concrete.OnShutdown(binding.ctx); err != nil {
+		if err := instance.shutdownBinding(key, binding); err != nil {
 			instance.mu.Unlock()
 			return zero, &ShutdownError{Type: serviceType.String(), Err: err}
 		}
 		binding.initialized = false
 	}
 
-	// Handle predicate
-	if binding.predicate != nil {
+	// Handle predicate, reusing the instance chosen earlier in the same request
+	if binding.hasCondition() {
 		instance.mu.Unlock()
-		result, err := binding.predicate(binding.ctx)
+		typed, err := chooseService[T](instance, key, binding, true)
 		if err != nil {
-			return zero, &PredicateError{Type: serviceType.String(), Err: err}
+			return zero, err
 		}
-		if typed, ok := result.(T); ok {
-			if err := typed.OnBoot(binding.ctx); err != nil {
-				return zero, &InitializationError{Type: serviceType.String(), Err: err}
-			}
-			return typed, nil
+		if binding.fresh && isCloneable(typed) {
+			typed = cloneService(typed).(T)
 		}
-		return zero, &PredicateError{Type: serviceType.String(), Err: fmt.Errorf("predicate returned invalid type")}
+		if err := instance.bootService(key, typed, instance.bootContext(key, bootCtx)); err != nil {
+			return zero, instance.initializationError(serviceType, err)
+		}
+		return typed, nil
 	}
 
 	concrete := binding.concrete
 	instance.mu.Unlock()
 
 	if typed, ok := concrete.(T); ok {
-		if err := typed.OnBoot(binding.ctx); err != nil {
-			return zero, &InitializationError{Type: serviceType.String(), Err: err}
+		if err := instance.bootService(key, typed, instance.bootContext(key, bootCtx)); err != nil {
+			return zero, instance.initializationError(serviceType, err)
 		}
 
 		instance.mu.Lock()
 		binding.initialized = true
-		instance.bindings[key] = binding
+		instance.bindings.Set(key, binding)
 		instance.mu.Unlock()
 
 		return typed, nil
@@ -264,62 +919,117 @@ func ResolveTransient[T Lifecycle]() (T, error) {
 }
 
 // ResolveRequest resolves a service with request scope.
-// Returns the same instance within a request context.
+// Returns the same instance within a request context. The request is the one
+// begun on the calling goroutine with BeginRequest or WithRequestScope, or else
+// the one the calling goroutine last bound T for.
 // Returns MissingContextValueError if request_id is not in context.
+// Returns InvalidContextValueError if the request_id is not a string.
 // Returns BindingNotFoundError if service is not registered.
+// Returns RequestEndedError if the request has already ended.
 func ResolveRequest[T Lifecycle]() (T, error) {
+	return wrapResult(resolveRequest[T](makeBindingKey(ScopeRequest, reflect.TypeOf((*T)(nil)).Elem())))
+}
+
+func resolveRequest[T Lifecycle](key bindingKey) (T, error) {
 	instance := GetContainer()
+	return boundedByDefault(instance, key, func() (T, error) {
+		return resolveRequestIn[T](instance, key)
+	})
+}
+
+func resolveRequestIn[T Lifecycle](instance *container, key bindingKey) (_ T, err error) {
+	defer func() { instance.recordEvent(EventResolve, key, err) }()
 	var zero T
-	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	serviceType := key.typ
 
-	// Create composite key for resolution chain
-	key := makeBindingKey(ScopeRequest, serviceType)
+	if typed, ok, err := intercept[T](instance, key); ok {
+		return typed, err
+	}
+	requested := key
+	key = instance.currentRequestKey(key)
 
 	// Check for circular dependency
 	if err := instance.startResolving(key); err != nil {
+		if partial, ok := partialInstance[T](instance, key, err); ok {
+			return partial, nil
+		}
 		return zero, err
 	}
 	defer instance.finishResolving(key)
-	instance.mu.RLock()
-	binding, ok := instance.bindings[key]
+	binding, ok := instance.getBinding(key)
 	if !ok {
-		instance.mu.RUnlock()
-		return zero, &BindingNotFoundError{Type: serviceType.String()}
+		if ctx := instance.activeRequest(); ctx != nil {
+			var err error
+			if binding, ok, err = instance.instantiateRequest(key, ctx); err != nil {
+				return zero, err
+			}
+		}
+		if !ok {
+			if owner := instance.parentOwner(requested); owner != nil {
+				var service T
+				owner.standIn(func() { service, err = resolveRequestIn[T](owner, requested) })
+				return service, err
+			}
+			if ended := instance.endedRequests.lookup(key, instance.getGoroutineID()); ended != "" {
+				return zero, &RequestEndedError{RequestID: ended}
+			}
+			return zero, instance.missingBinding(key)
+		}
 	}
-	requestID := binding.ctx.Value("request_id")
-	if requestID == nil {
-		instance.mu.RUnlock()
-
-		return zero, &MissingContextValueError{Key: "request_id"}
+	if binding.owner != nil {
+		return resolveRequestIn[T](binding.owner, requested)
+	}
+	if err := checkRequestID(binding.ctx); err != nil {
+		return zero, err
 	}
 
 	// Check if already initialized
-	if binding.initialized {
+	refresh := binding.initialized && instance.claimRefresh(key)
+	if binding.initialized && !refresh {
 		if typed, ok := binding.concrete.(T); ok {
-			instance.mu.RUnlock()
 			return typed, nil
 		}
-		instance.mu.RUnlock()
 		return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
 	}
-	instance.mu.RUnlock()
 
-	if binding.predicate != nil {
-		result, err := binding.predicate(binding.ctx)
+	// Serialize initialization of this request instance, so goroutines sharing
+	// the request through WithRequestScope boot it once
+	lock := instance.initLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Re-read the binding now that no other initialization is in flight
+	binding, ok = instance.getBinding(key)
+	if !ok {
+		return zero, instance.missingBinding(key)
+	}
+	if binding.initialized && !refresh {
+		// Initialized by another goroutine while this one waited for the lock
+		if typed, ok := binding.concrete.(T); ok {
+			return typed, nil
+		}
+		return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+	}
+	if binding.initialized {
+		if err := instance.shutdownBinding(key, binding); err != nil {
+			return zero, &ShutdownError{Type: serviceType.String(), Err: err}
+		}
+		binding.initialized = false
+	}
+
+	if binding.hasCondition() {
+		result, err := chooseService[T](instance, key, binding, false)
 		if err != nil {
-			return zero, &PredicateError{Type: serviceType.String(), Err: err}
+			return zero, err
 		}
-		binding.concrete = result.(T)
+		binding.setConcrete(result)
 	}
-	if err := binding.concrete.OnBoot(binding.ctx); err != nil {
-		return zero, &InitializationError{Type: serviceType.String(), Err: err}
+	if err := instance.bootService(key, binding.concrete, instance.bootContext(key, binding.ctx)); err != nil {
+		return zero, instance.initializationError(serviceType, err)
 	}
 
-	// Update binding under lock
-	instance.mu.Lock()
 	binding.initialized = true
-	instance.bindings[key] = binding
-	instance.mu.Unlock()
+	instance.storeBinding(key, binding)
 
 	return binding.concrete.(T), nil
 }
@@ -329,106 +1039,454 @@ func ResolveRequest[T Lifecycle]() (T, error) {
 // Returns BindingNotFoundError if service is not registered.
 // Returns InitializationError if service fails to initialize.
 func ResolveSingleton[T Lifecycle]() (T, error) {
-	var zero T
+	return wrapResult(resolveSingleton[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem())))
+}
+
+// ResolveSingletonOr resolves the singleton bound for T, returning fallback if
+// it is not bound or fails to resolve, for optional services such as a no-op
+// metrics sink.
+func ResolveSingletonOr[T Lifecycle](fallback T) T {
+	service, err := resolveSingleton[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem()))
+	if err != nil {
+		return fallback
+	}
+	return service
+}
+
+// ResolveMap resolves the singleton bound for T and returns fn applied to it,
+// for callers that only need one value derived from the service.
+// fn is not called if the resolution fails.
+// Returns the errors of ResolveSingleton.
+func ResolveMap[T Lifecycle, R any](fn func(T) R) (R, error) {
+	service, err := ResolveSingleton[T]()
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	return fn(service), nil
+}
+
+// ResolveInfo describes how a resolution was served.
+type ResolveInfo struct {
+	// CacheHit is true if the service was already initialized and OnBoot did not run.
+	CacheHit bool
+	// BootDuration is the time spent in Construct and OnBoot, zero on a cache hit.
+	BootDuration time.Duration
+}
+
+// ResolveSingletonDetailed resolves a singleton like ResolveSingleton and
+// reports whether it was served from the initialized cache or booted, and how
+// long booting took.
+func ResolveSingletonDetailed[T Lifecycle]() (T, ResolveInfo, error) {
+	service, info, err := resolveSingletonDetailed[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem()))
+	return service, info, GetContainer().wrapError(err)
+}
+
+// ResolveSingletonConcrete resolves the singleton bound as T and returns its
+// concrete implementation *C, saving call sites a type assertion.
+// Returns TypeMismatchError if the bound service is not a *C.
+// Returns the errors of ResolveSingleton otherwise.
+func ResolveSingletonConcrete[T Lifecycle, C any]() (*C, error) {
+	service, err := resolveSingleton[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem()))
+	if err != nil {
+		return nil, GetContainer().wrapError(err)
+	}
+	concrete, ok := any(service).(*C)
+	if !ok {
+		return nil, GetContainer().wrapError(&TypeMismatchError{Expected: reflect.TypeOf((*C)(nil)).String(), Got: reflect.TypeOf(service).String()})
+	}
+	return concrete, nil
+}
+
+func resolveSingleton[T Lifecycle](key bindingKey) (T, error) {
+	service, _, err := resolveSingletonDetailed[T](key)
+	return service, err
+}
+
+// resolveSingletonDetailed resolves a singleton like resolveSingleton and
+// reports whether it was served from the initialized cache.
+func resolveSingletonDetailed[T Lifecycle](key bindingKey) (T, ResolveInfo, error) {
 	instance := GetContainer()
-	serviceType := reflect.TypeOf((*T)(nil)).Elem()
-	key := makeBindingKey(ScopeSingleton, serviceType)
+	type detailed struct {
+		service T
+		info    ResolveInfo
+	}
+	result, err := boundedByDefault(instance, key, func() (detailed, error) {
+		service, info, err := resolveSingletonIn[T](instance, key)
+		return detailed{service: service, info: info}, err
+	})
+	return result.service, result.info, err
+}
 
-	// Get binding under read lock
-	instance.mu.RLock()
-	binding, ok := instance.bindings[key]
-	instance.mu.RUnlock()
+func resolveSingletonIn[T Lifecycle](instance *container, key bindingKey) (_ T, info ResolveInfo, err error) {
+	var zero T
+	defer func() { instance.recordEvent(EventResolve, key, err) }()
+	serviceType := key.typ
+
+	if typed, ok, err := intercept[T](instance, key); ok {
+		return typed, info, err
+	}
+
+	// Get binding under read lock, reading it again after any reboot in progress
+	binding, ok := instance.getBinding(key)
+	for instance.awaitReboot(key) {
+		binding, ok = instance.getBinding(key)
+	}
+	if ok && binding.owner != nil {
+		return resolveSingletonIn[T](binding.owner, key)
+	}
+	if ok && binding.isAlias() {
+		return resolveSingletonIn[T](instance, binding.aliasOf)
+	}
 
 	if !ok {
-		return zero, &BindingNotFoundError{Type: serviceType.String()}
+		// Bindings missing here are resolved, and owned, by the nearest parent
+		if owner := instance.parentOwner(key); owner != nil {
+			var service T
+			owner.standIn(func() { service, info, err = resolveSingletonIn[T](owner, key) })
+			return service, info, err
+		}
+		// The container resolves itself unless Resolver was bound explicitly
+		if key.typ == resolverType && key.name == "" && key.scope == ScopeSingleton {
+			if typed, ok := Lifecycle(containerResolver{c: instance}).(T); ok {
+				return typed, info, nil
+			}
+		}
+		return zero, info, instance.missingBinding(key)
+	}
+
+	// Fast path: an initialized singleton cannot be part of an in-flight chain, so
+	// unless a ResolveFresh pass may need to re-boot it, skip the resolution state.
+	if binding.initialized && instance.freshPasses.Load() == 0 && !instance.closing.Load() {
+		if typed, ok := binding.typed.(*T); ok {
+			return *typed, ResolveInfo{CacheHit: true}, nil
+		}
+		if typed, ok := binding.concrete.(T); ok {
+			return typed, ResolveInfo{CacheHit: true}, nil
+		}
+		return zero, info, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
 	}
 
 	// Check for circular dependency
 	if err := instance.startResolving(key); err != nil {
-		return zero, err
+		if partial, ok := partialInstance[T](instance, key, err); ok {
+			return partial, info, nil
+		}
+		return zero, info, err
 	}
 	defer instance.finishResolving(key)
 
-	// Check if already initialized under read lock
-	instance.mu.RLock()
-	initialized := binding.initialized
-	instance.mu.RUnlock()
-
-	if initialized {
+	refresh := instance.claimRefresh(key)
+	if binding.initialized && !refresh {
 		if typed, ok := binding.concrete.(T); ok {
-			return typed, nil
+			return typed, ResolveInfo{CacheHit: true}, nil
 		}
-		return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+		return zero, info, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
 	}
 
-	// Initialize under write lock
-	instance.mu.Lock()
-	// Double-check initialization status after acquiring lock
-	if !binding.initialized {
-		if err := binding.concrete.OnBoot(binding.ctx); err != nil {
-			instance.mu.Unlock()
-			return zero, &InitializationError{Type: serviceType.String(), Err: err}
+	// Serialize initialization of this singleton without holding the container
+	// lock, so OnBoot is free to resolve its own dependencies.
+	lock := instance.initLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Re-read the binding now that no other initialization is in flight
+	binding, ok = instance.getBinding(key)
+	if !ok {
+		return zero, info, instance.missingBinding(key)
+	}
+
+	if binding.initialized && refresh {
+		if err := instance.shutdownBinding(key, binding); err != nil {
+			return zero, info, &ShutdownError{Type: serviceType.String(), Err: err}
+		}
+		binding.initialized = false
+		instance.storeBinding(key, binding)
+	}
+
+	if binding.initialized {
+		// Initialized by another goroutine while this one waited for the lock
+		info.CacheHit = true
+	} else {
+		if binding.concrete == nil {
+			var err error
+			if binding, err = binding.materialize(); err != nil {
+				return zero, info, instance.initializationError(serviceType, err)
+			}
+			instance.storeBinding(key, binding)
+		}
+		start := time.Now()
+		err := instance.bootService(key, binding.concrete, instance.bootContext(key, binding.ctx))
+		info.BootDuration = time.Since(start)
+		if err != nil {
+			return zero, info, instance.initializationError(serviceType, err)
 		}
 		binding.initialized = true
-		instance.bindings[key] = binding
+		instance.storeBinding(key, binding)
 	}
-	concrete := binding.concrete
-	instance.mu.Unlock()
 
-	if typed, ok := concrete.(T); ok {
-		return typed, nil
+	if typed, ok := binding.concrete.(T); ok {
+		return typed, info, nil
 	}
-	return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+	return zero, info, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+}
+
+// PeekSingleton returns a singleton without triggering its initialization.
+// The boolean is true only if the singleton is already initialized.
+// Returns BindingNotFoundError if service is not registered.
+func PeekSingleton[T Lifecycle]() (T, bool, error) {
+	service, ok, err := peekSingleton[T]()
+	return service, ok, GetContainer().wrapError(err)
+}
+
+func peekSingleton[T Lifecycle]() (T, bool, error) {
+	var zero T
+	instance := GetContainer()
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	key := makeBindingKey(ScopeSingleton, serviceType)
+
+	binding, ok := instance.getBinding(key)
+
+	if !ok {
+		return zero, false, instance.missingBinding(key)
+	}
+	if !binding.initialized {
+		return zero, false, nil
+	}
+	if typed, ok := binding.concrete.(T); ok {
+		return typed, true, nil
+	}
+	return zero, false, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(binding.concrete).String()}
+}
+
+// IsBound reports whether T is registered with the given scope.
+// It never initializes the service.
+func IsBound[T Lifecycle](scope Scope) bool {
+	return GetContainer().isBound(reflect.TypeOf((*T)(nil)).Elem(), scope)
+}
+
+func (c *container) isBound(serviceType reflect.Type, scope Scope) bool {
+	key := makeBindingKey(scope, serviceType)
+	if scope == ScopeRequest {
+		key = c.currentRequestKey(key)
+	}
+
+	c.mu.RLock()
+	_, ok := c.bindings.Get(key)
+	c.mu.RUnlock()
+	if !ok && c.parent != nil {
+		return c.parent.isBound(serviceType, scope)
+	}
+	return ok
+}
+
+// ResolveFresh resolves a singleton while ignoring the initialized cache.
+// The target and every singleton or request service it resolves during OnBoot
+// are shut down (if initialized) and booted again, each at most once per call.
+// Unrelated bindings are not touched and circular dependencies are still detected.
+func ResolveFresh[T Lifecycle]() (T, error) {
+	instance := GetContainer()
+	state := instance.beginFresh()
+	defer instance.endFresh(state)
+	return wrapResult(resolveSingleton[T](makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem())))
+}
+
+// SetBaseValue stores a value in the container's base context.
+// Every binding merges the base context at bind time, so the value is visible to
+// bindings registered after this call only. Existing bindings keep the snapshot
+// of the base context taken when they were bound. By default the base value
+// overrides a binding context value with the same key; see SetBaseMergePolicy.
+func SetBaseValue(key, val interface{}) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.ctx = instance.ctx.WithValue(key, val)
+	instance.mu.Unlock()
 }
 
 // Reset clears all container state.
 // This function is intended for testing purposes only.
 // It removes all bindings and resets the container to its initial state.
+// Values set with SetBaseValue, the resolve interceptor, the error wrapper, the
+// circular and base merge policies, the seal mode, the boot hooks and pending
+// shutdown hooks are discarded, and a drained container accepts resolutions again.
+// With leak detection enabled, initialized request and transient bindings are reported.
+// While the container is frozen, Reset only logs that it was ignored.
 func Reset() {
 	instance := GetContainer()
 	instance.mu.Lock()
+	if instance.frozen {
+		logger := instance.logger
+		instance.mu.Unlock()
+		if logger != nil {
+			logger.Printf("digo: %v", &ContainerFrozenError{Op: "reset"})
+		}
+		return
+	}
 	instance.resolutionMu.Lock()
 
-	instance.bindings = make(map[string]bindingDefinition)
-	instance.resolutionState = sync.Map{}
+	leaks := instance.collectLeaks(instance.snapshot())
+
+	instance.clearBindings()
+	instance.latestRequest = make(map[bindingKey]latestBinding)
+	instance.ctx = NewContainerContext(context.Background())
+	instance.interceptor.Store(nil)
+	instance.errorWrapper.Store(nil)
+	instance.closing.Store(false)
+	instance.circularPolicy = CircularError
+	instance.transientPolicy = ReuseAndReinit
+	instance.baseMergePolicy = BaseWins
+	instance.shutdownHooks = nil
+	instance.dependsOn = nil
+	instance.beforeBoot = nil
+	instance.afterBoot = nil
+	instance.sealAfterBoot = false
+	instance.clearResolutionStates()
+	instance.clearRequests()
 	instance.booted = false
 	instance.bootOnce = sync.Once{}
 
 	instance.resolutionMu.Unlock()
 	instance.mu.Unlock()
+
+	instance.reportLeaks(leaks)
 }
 
-func (c *container) bind(service Lifecycle, serviceType reflect.Type, scope Scope, ctx *ContainerContext, predicate ...ContextPredicate) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// ResetScope removes every binding of scope, calling OnShutdown on the
+// initialized ones in reverse registration order, and clears the resolution
+// state. Bindings of other scopes and container settings are kept. Resetting
+// ScopeRequest also ends every BeginRequest association, and resetting
+// ScopeSingleton lets Boot run again.
+// Returns ContainerFrozenError while the container is frozen.
+// Returns the first ShutdownError encountered; the bindings are removed regardless.
+func ResetScope(scope Scope) error {
+	instance := GetContainer()
+	instance.mu.Lock()
+	if instance.frozen {
+		instance.mu.Unlock()
+		return instance.wrapError(&ContainerFrozenError{Op: "reset scope " + string(scope)})
+	}
+	var removed []bindingDefinition
+	for _, binding := range instance.snapshot() {
+		if binding.scope != scope {
+			continue
+		}
+		instance.bindings.Delete(binding.key)
+		instance.initLocks.Delete(binding.key)
+		binding.stopReleasing()
+		removed = append(removed, binding)
+	}
+	switch scope {
+	case ScopeRequest:
+		instance.latestRequest = make(map[bindingKey]latestBinding)
+		instance.clearRequests()
+	case ScopeSingleton:
+		instance.booted = false
+		instance.bootOnce = sync.Once{}
+	}
+	instance.resolutionMu.Lock()
+	instance.clearResolutionStates()
+	instance.resolutionMu.Unlock()
+	instance.mu.Unlock()
 
-	if reflect.ValueOf(service).IsNil() {
-		return &NilServiceError{Type: serviceType.String()}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].id > removed[j].id })
+	var firstErr error
+	for _, binding := range removed {
+		if !binding.initialized {
+			continue
+		}
+		if err := instance.shutdownBinding(binding.key, binding); err != nil && firstErr == nil {
+			firstErr = &ShutdownError{Type: reflect.TypeOf(binding.concrete).String(), Err: err}
+		}
 	}
+	return instance.wrapError(firstErr)
+}
 
-	bindingCtx := ctx
-	if bindingCtx == nil {
-		bindingCtx = c.ctx
+func (c *container) bind(service Lifecycle, serviceType reflect.Type, scope Scope, ctx *ContainerContext, predicate ...ContextPredicate) error {
+	// The sharded store is safe for concurrent use, so binds share the lock
+	// and only wait for operations that need the container to themselves.
+	// Request bindings update the per-request bookkeeping and stay exclusive.
+	if _, sharded := c.bindings.(*shardedStore); sharded && scope != ScopeRequest {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	} else {
+		c.mu.Lock()
+		defer c.mu.Unlock()
 	}
-	bindingCtx = bindingCtx.MergeWith(c.ctx)
 
-	var pred ContextPredicate
+	binding, err := c.newBinding(service, serviceType, scope, ctx)
+	if err != nil {
+		return err
+	}
 	if len(predicate) > 0 {
-		pred = predicate[0]
+		binding.predicate = predicate[0]
 	}
 
-	key := makeBindingKey(scope, serviceType)
-	c.bindings[key] = bindingDefinition{
-		scope:       scope,
-		concrete:    service,
-		abstract:    serviceType,
-		initialized: false,
-		ctx:         bindingCtx,
-		predicate:   pred,
-	}
+	c.register(makeBindingKey(scope, serviceType), binding)
 	return nil
 }
 
+// register stores a new binding under key.
+// Callers must hold c.mu, which may be shared for a non-request binding in
+// the sharded store.
+func (c *container) register(key bindingKey, binding bindingDefinition) {
+	if binding.scope == ScopeRequest {
+		key = c.requestKey(key, binding.ctx)
+	}
+	binding.key = key
+	binding.stopRelease = nil
+	if binding.scope == ScopeRequest {
+		binding.stopRelease = c.releaseOnDone(key, binding)
+	}
+	if replaced, ok := c.bindings.Get(key); ok {
+		replaced.stopReleasing()
+	}
+	c.bindings.Set(key, binding)
+	c.recordEvent(EventBind, key, nil)
+	c.notifyBound(key)
+}
+
+// newBinding validates a service and builds its binding definition.
+// Callers must hold c.mu, shared or exclusive.
+func (c *container) newBinding(service Lifecycle, serviceType reflect.Type, scope Scope, ctx *ContainerContext) (bindingDefinition, error) {
+	if err := c.checkSealed(serviceType); err != nil {
+		return bindingDefinition{}, err
+	}
+	if isNilService(service) {
+		return bindingDefinition{}, &NilServiceError{Type: serviceType.String()}
+	}
+
+	bindingCtx := c.withBase(ctx)
+	if err := checkScopeID(scope, bindingCtx); err != nil {
+		return bindingDefinition{}, err
+	}
+
+	binding := bindingDefinition{
+		scope:         scope,
+		abstract:      serviceType,
+		interfaceType: serviceType.Kind() == reflect.Interface,
+		id:            c.nextID.Add(1),
+		initialized:   false,
+		ctx:           bindingCtx,
+	}
+	binding.setConcrete(service)
+	return binding, nil
+}
+
+// isNilService reports whether service is nil or a nil pointer, map, slice,
+// func or channel. Services of other kinds, such as struct values bound under
+// their own concrete type, are never nil.
+func isNilService(service Lifecycle) bool {
+	if service == nil {
+		return true
+	}
+	value := reflect.ValueOf(service)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
 // Add methods to track resolution chain
 func (c *container) getResolutionState() *resolutionState {
 	id := c.getGoroutineID() // Get ID first to minimize lock time
@@ -455,44 +1513,327 @@ func (c *container) getResolutionState() *resolutionState {
 	return state.(*resolutionState)
 }
 
-func (c *container) startResolving(key string) error {
+func (c *container) startResolving(key bindingKey) error {
 	state := c.getResolutionState()
 	state.mu.Lock()
-	defer state.mu.Unlock()
 
 	if state.chain[key] {
-		return &CircularDependencyError{Type: key}
+		state.mu.Unlock()
+		return &CircularDependencyError{Type: key.String()}
+	}
+	// While draining, new top-level resolutions are rejected but nested ones
+	// are admitted so the work already in flight can finish.
+	if len(state.chain) == 0 && c.closing.Load() {
+		release := state.holds == 0
+		state.mu.Unlock()
+		if release {
+			c.releaseResolutionState()
+		}
+		return &ContainerClosingError{Type: key.typ.String()}
 	}
 	state.chain[key] = true
 	state.keyCache = append(state.keyCache, key)
+	if c.slowThreshold.Load() > 0 {
+		if state.started == nil {
+			state.started = make(map[bindingKey]resolveStart)
+		}
+		state.started[key] = resolveStart{at: time.Now()}
+	}
+	state.mu.Unlock()
 	return nil
 }
 
-func (c *container) finishResolving(key string) {
+func (c *container) finishResolving(key bindingKey) {
 	state := c.getResolutionState()
 	state.mu.Lock()
 	delete(state.chain, key)
-	isEmpty := len(state.chain) == 0
+	delete(state.contexts, key)
+	delete(state.started, key)
+	for i := len(state.keyCache) - 1; i >= 0; i-- {
+		if state.keyCache[i] == key {
+			state.keyCache = append(state.keyCache[:i], state.keyCache[i+1:]...)
+			break
+		}
+	}
+	isEmpty := len(state.chain) == 0 && state.holds == 0
 	state.mu.Unlock()
 
 	if isEmpty {
-		c.resolutionMu.Lock()
-		id := c.getGoroutineID()
-		if s, ok := c.resolutionState.Load(id); ok {
-			c.resolutionState.Delete(id)
-			rs := s.(*resolutionState)
-			for _, k := range rs.keyCache {
-				delete(rs.chain, k)
-			}
-			rs.keyCache = rs.keyCache[:0]
-			c.statePool.Put(rs)
+		c.releaseResolutionState()
+	}
+}
+
+// ClearResolutionState discards the resolution state of every goroutine.
+// It is a recovery tool for states leaked by a goroutine that never finished
+// resolving, which would otherwise report a stale CircularDependencyError to a
+// goroutine that reuses its ID. Call it only while no resolution is in flight.
+func ClearResolutionState() {
+	instance := GetContainer()
+	instance.resolutionMu.Lock()
+	instance.clearResolutionStates()
+	instance.resolutionMu.Unlock()
+}
+
+// clearResolutionStates drops all resolution states without returning them to
+// the pool, since a leaked state may still be referenced.
+// Callers must hold c.resolutionMu.
+func (c *container) clearResolutionStates() {
+	c.resolutionState = sync.Map{}
+}
+
+// releaseResolutionState returns the current goroutine's state to the pool.
+func (c *container) releaseResolutionState() {
+	c.resolutionMu.Lock()
+	defer c.resolutionMu.Unlock()
+
+	id := c.getGoroutineID()
+	if s, ok := c.resolutionState.Load(id); ok {
+		c.resolutionState.Delete(id)
+		rs := s.(*resolutionState)
+		for _, k := range rs.keyCache {
+			delete(rs.chain, k)
 		}
-		c.resolutionMu.Unlock()
+		rs.keyCache = rs.keyCache[:0]
+		rs.holds = 0
+		rs.fresh = 0
+		rs.refreshed = nil
+		rs.deadline = nil
+		rs.contexts = nil
+		rs.started = nil
+		c.statePool.Put(rs)
+	}
+}
+
+// pinState keeps the current goroutine's state alive across resolutions until
+// the matching unpinState, so per-call settings survive an empty chain.
+func (c *container) pinState() *resolutionState {
+	state := c.getResolutionState()
+	state.mu.Lock()
+	state.holds++
+	state.mu.Unlock()
+	return state
+}
+
+// unpinState undoes pinState and releases the state once nothing holds it.
+func (c *container) unpinState(state *resolutionState) {
+	state.mu.Lock()
+	state.holds--
+	isEmpty := len(state.chain) == 0 && state.holds == 0
+	state.mu.Unlock()
+
+	if isEmpty {
+		c.releaseResolutionState()
+	}
+}
+
+// beginFresh marks the current goroutine's resolutions as bypassing the initialized cache.
+func (c *container) beginFresh() *resolutionState {
+	c.freshPasses.Add(1)
+	state := c.pinState()
+	state.mu.Lock()
+	state.fresh++
+	if state.refreshed == nil {
+		state.refreshed = make(map[bindingKey]bool)
+	}
+	state.mu.Unlock()
+	return state
+}
+
+// endFresh undoes beginFresh.
+func (c *container) endFresh(state *resolutionState) {
+	state.mu.Lock()
+	state.fresh--
+	if state.fresh == 0 {
+		state.refreshed = nil
+	}
+	state.mu.Unlock()
+	c.unpinState(state)
+	c.freshPasses.Add(-1)
+}
+
+// claimRefresh reports whether key must be re-booted by an active ResolveFresh pass.
+// Each key is claimed at most once per pass.
+func (c *container) claimRefresh(key bindingKey) bool {
+	state := c.getResolutionState()
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.fresh == 0 || state.refreshed[key] {
+		return false
+	}
+	state.refreshed[key] = true
+	return true
+}
+
+// bootContext derives the context passed to OnBoot for key, carrying the current
+// resolution depth. The context is remembered for dependencies that inherit it.
+// A scratch ctx, and any scratch context derived on the way, is released.
+func (c *container) bootContext(key bindingKey, ctx *ContainerContext) *ContainerContext {
+	state := c.getResolutionState()
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	depth := len(state.keyCache) - 1
+	if depth < 0 {
+		depth = 0
+	}
+	if state.deadline != nil {
+		bounded := ctx.withCancellation(state.deadline)
+		releaseContext(ctx)
+		ctx = bounded
+	}
+	bootCtx := ctx.WithValue(ResolutionDepthKey, depth)
+	releaseContext(ctx)
+	if state.contexts == nil {
+		state.contexts = make(map[bindingKey]*ContainerContext)
+	}
+	state.contexts[key] = bootCtx
+	return bootCtx
+}
+
+// inheritContext merges the boot context of the resolving service into ctx.
+// The merge is a scratch context meant to be passed to bootContext.
+func (c *container) inheritContext(ctx *ContainerContext) *ContainerContext {
+	state := c.getResolutionState()
+	state.mu.Lock()
+	var caller *ContainerContext
+	if n := len(state.keyCache); n > 1 {
+		caller = state.contexts[state.keyCache[n-2]]
+	}
+	state.mu.Unlock()
+
+	if caller == nil {
+		return ctx
+	}
+	merged, _ := ctx.mergeInto(acquireContext(ctx.Context), caller, false)
+	return merged
+}
+
+// bootService runs Construct and OnBoot for a service resolved or booted under key.
+func (c *container) bootService(key bindingKey, service Lifecycle, ctx *ContainerContext) error {
+	if err := c.construct(service); err != nil {
+		c.recordEvent(EventBoot, key, err)
+		return err
+	}
+	start := time.Now()
+	err := service.OnBoot(ctx)
+	c.stats.bootTime.Add(int64(time.Since(start)))
+	c.recordEvent(EventBoot, key, err)
+	if err == nil {
+		c.guard(key, service)
+	}
+	return err
+}
+
+// shutdownBinding runs OnShutdown for binding, bounded by its shutdown timeout
+// if it has one. OnShutdown receives a context carrying the deadline; a service
+// that overruns it is abandoned and context.DeadlineExceeded is returned.
+func (c *container) shutdownBinding(key bindingKey, binding bindingDefinition) error {
+	if binding.shutdownTimeout <= 0 {
+		return c.shutdownService(key, binding.concrete, binding.ctx)
+	}
+	ctx, cancel := binding.ctx.WithTimeout(binding.shutdownTimeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.shutdownService(key, binding.concrete, ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shutdownService runs OnShutdown for a service bound under key.
+func (c *container) shutdownService(key bindingKey, service Lifecycle, ctx *ContainerContext) error {
+	err := service.OnShutdown(ctx)
+	c.recordEvent(EventShutdown, key, err)
+	if err == nil {
+		c.unguard(service)
+	}
+	return err
+}
+
+// initializationError wraps a failed OnBoot, recording the current resolution chain.
+func (c *container) initializationError(serviceType reflect.Type, err error) *InitializationError {
+	state := c.getResolutionState()
+	state.mu.Lock()
+	chain := make([]string, len(state.keyCache))
+	for i, key := range state.keyCache {
+		chain[i] = key.String()
+	}
+	state.mu.Unlock()
+
+	return &InitializationError{Type: serviceType.String(), Err: err, Chain: chain}
+}
+
+// initLock returns the mutex serializing initialization of the binding under key.
+func (c *container) initLock(key bindingKey) *sync.Mutex {
+	if lock, ok := c.initLocks.Load(key); ok {
+		return lock.(*sync.Mutex)
+	}
+	lock, _ := c.initLocks.LoadOrStore(key, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// missingBinding explains why key has no binding. It returns ScopeMismatchError
+// if the type is bound under another scope, InstantiationMismatchError if a
+// generic type is bound under another instantiation, and BindingNotFoundError
+// otherwise.
+func (c *container) missingBinding(key bindingKey) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	generic := genericName(key.typ)
+	var bound, instantiation *bindingDefinition
+	c.bindings.Range(func(k bindingKey, binding bindingDefinition) bool {
+		if k.name != key.name {
+			return true
+		}
+		if k.typ == key.typ && k.scope != key.scope && (bound == nil || binding.id < bound.id) {
+			bound = &binding
+		}
+		if generic != "" && k.typ != key.typ && genericName(k.typ) == generic &&
+			(instantiation == nil || binding.id < instantiation.id) {
+			instantiation = &binding
+		}
+		return true
+	})
+	if bound != nil {
+		return &ScopeMismatchError{Type: key.typ.String(), BoundScope: bound.scope, RequestedScope: key.scope}
+	}
+	if instantiation != nil {
+		return &InstantiationMismatchError{Requested: key.typ.String(), Bound: instantiation.key.typ.String()}
+	}
+	if key.scope == ScopeRequest {
+		return &BindingNotFoundError{Type: key.typ.String(), Request: key.request}
+	}
+	return &BindingNotFoundError{Type: key.typ.String()}
+}
+
+// genericName returns the package-qualified name of the generic type t
+// instantiates, or "" if t is not an instantiated generic type.
+func genericName(t reflect.Type) string {
+	name := t.Name()
+	i := strings.IndexByte(name, '[')
+	if i < 0 {
+		return ""
+	}
+	return t.PkgPath() + "." + name[:i]
+}
+
+// storeBinding writes back an updated binding unless it was rebound or removed meanwhile.
+func (c *container) storeBinding(key bindingKey, binding bindingDefinition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, ok := c.bindings.Get(key); ok && current.id == binding.id {
+		c.bindings.Set(key, binding)
 	}
 }
 
 func (c *container) getGoroutineID() string {
-	id := goid()
+	id := c.goroutines.ID()
 	if cached, ok := c.goidCache.Load(id); ok {
 		return cached.(string)
 	}
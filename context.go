@@ -2,14 +2,53 @@ package digo
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
+// ResolutionDepthKey is the context key under which OnBoot receives its nesting
+// depth in the current resolution chain. Top-level resolutions have depth 0.
+const ResolutionDepthKey = "digo.resolution_depth"
+
 // ContainerContext extends the standard context.Context with container-specific functionality.
 // It provides value inheritance and merging capabilities for service configuration.
 type ContainerContext struct {
 	context.Context
 	values sync.Map
+	// pooled marks scratch contexts taken from contextPool that have not been
+	// released yet. Contexts handed to services or callers are never pooled.
+	pooled bool
+}
+
+// contextPool recycles the scratch contexts the container derives while
+// building boot contexts, which are discarded once the final context exists.
+var contextPool = sync.Pool{
+	New: func() any { return new(ContainerContext) },
+}
+
+// acquireContext returns an empty scratch context on top of parent.
+// It must be passed to releaseContext, and not be retained, once it is no
+// longer read.
+func acquireContext(parent context.Context) *ContainerContext {
+	ctx := contextPool.Get().(*ContainerContext)
+	ctx.Context = parent
+	ctx.pooled = true
+	return ctx
+}
+
+// releaseContext resets a scratch context and returns it to the pool. Contexts
+// not obtained from acquireContext, or already released, are left untouched.
+func releaseContext(ctx *ContainerContext) {
+	if ctx == nil || !ctx.pooled {
+		return
+	}
+	ctx.pooled = false
+	ctx.Context = nil
+	ctx.values.Clear()
+	contextPool.Put(ctx)
 }
 
 // NewContainerContext creates a new ContainerContext wrapping a standard context.Context.
@@ -26,15 +65,59 @@ func NewContainerContext(parent context.Context) *ContainerContext {
 // WithValue returns a new ContainerContext with the provided key-value pair.
 // The new context inherits all values from the parent context.
 func (c *ContainerContext) WithValue(key, val interface{}) *ContainerContext {
-	newCtx := &ContainerContext{
-		Context: c.Context,
-	}
+	newCtx := c.withParent(c.Context)
+	newCtx.values.Store(key, val)
+	return newCtx
+}
+
+// WithCancel returns a copy of the context with its own cancellation, wrapping
+// context.WithCancel while preserving stored values.
+func (c *ContainerContext) WithCancel() (*ContainerContext, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Context)
+	return c.withParent(ctx), cancel
+}
+
+// WithTimeout returns a copy of the context that is cancelled after d, wrapping
+// context.WithTimeout while preserving stored values.
+func (c *ContainerContext) WithTimeout(d time.Duration) (*ContainerContext, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(c.Context, d)
+	return c.withParent(ctx), cancel
+}
+
+// withParent returns a copy of the context with the same stored values on top of parent.
+func (c *ContainerContext) withParent(parent context.Context) *ContainerContext {
+	return c.copyInto(&ContainerContext{Context: parent})
+}
+
+// copyInto stores the values of c in dst and returns dst.
+func (c *ContainerContext) copyInto(dst *ContainerContext) *ContainerContext {
 	c.values.Range(func(k, v interface{}) bool {
-		newCtx.values.Store(k, v)
+		dst.values.Store(k, v)
 		return true
 	})
-	newCtx.values.Store(key, val)
-	return newCtx
+	return dst
+}
+
+// WithLazyValue returns a new ContainerContext where key maps to the result of fn.
+// fn is invoked at most once, on the first Value(key) lookup, and the result is
+// memoized. Contexts derived from the returned one share the memoized value.
+func (c *ContainerContext) WithLazyValue(key interface{}, fn func() interface{}) *ContainerContext {
+	return c.WithValue(key, &lazyValue{fn: fn})
+}
+
+// lazyValue defers computing a context value until it is first read.
+type lazyValue struct {
+	once sync.Once
+	fn   func() interface{}
+	val  interface{}
+}
+
+func (l *lazyValue) get() interface{} {
+	l.once.Do(func() {
+		l.val = l.fn()
+		l.fn = nil
+	})
+	return l.val
 }
 
 func (c *ContainerContext) Parent() context.Context {
@@ -46,6 +129,9 @@ func (c *ContainerContext) Value(key interface{}) interface{} {
 		return nil
 	}
 	if val, ok := c.values.Load(key); ok {
+		if lazy, ok := val.(*lazyValue); ok {
+			return lazy.get()
+		}
 		return val
 	}
 	if c.Context != nil {
@@ -54,29 +140,134 @@ func (c *ContainerContext) Value(key interface{}) interface{} {
 	return nil
 }
 
+// String renders the values stored locally in the context, sorted by key, for
+// debug output such as ContainerContext{env: prod, request_id: req-1}.
+// Values inherited from the parent context.Context are not included, keys
+// registered with RedactKeys are masked and lazy values are not evaluated.
+func (c *ContainerContext) String() string {
+	if c == nil {
+		return "ContainerContext{}"
+	}
+	instance := GetContainer()
+	var pairs []string
+	c.values.Range(func(k, v interface{}) bool {
+		if _, ok := v.(*lazyValue); ok {
+			v = "<lazy>"
+		}
+		pairs = append(pairs, fmt.Sprintf("%v: %v", k, instance.redactValue(k, v)))
+		return true
+	})
+	sort.Strings(pairs)
+	return "ContainerContext{" + strings.Join(pairs, ", ") + "}"
+}
+
 // Values returns the underlying sync.Map of values stored in the context.
+// Values added with WithLazyValue are stored unevaluated; use Value to read them.
 func (c *ContainerContext) Values() *sync.Map {
 	return &c.values
 }
 
+// Range calls fn for each value stored in the context, in no particular
+// order, until fn returns false. Values added with WithLazyValue are evaluated
+// as Value would. Only values stored on the ContainerContext are visited:
+// values of the parent context.Context cannot be enumerated, so they are
+// reachable through Value only.
+func (c *ContainerContext) Range(fn func(key, value interface{}) bool) {
+	c.values.Range(func(k, v interface{}) bool {
+		if lazy, ok := v.(*lazyValue); ok {
+			v = lazy.get()
+		}
+		return fn(k, v)
+	})
+}
+
 // MergeWith combines values from another ContainerContext.
 // Values from the other context override existing values with the same key.
 func (c *ContainerContext) MergeWith(other *ContainerContext) *ContainerContext {
-	newCtx := NewContainerContext(c.Context)
+	newCtx, _ := c.merge(other, false)
+	return newCtx
+}
+
+// MergeWithTracked behaves like MergeWith and also returns the keys whose values
+// in c were overridden by other, formatted with fmt.Sprint and sorted.
+func (c *ContainerContext) MergeWithTracked(other *ContainerContext) (*ContainerContext, []string) {
+	return c.merge(other, true)
+}
 
+func (c *ContainerContext) merge(other *ContainerContext, track bool) (*ContainerContext, []string) {
+	return c.mergeInto(NewContainerContext(c.Context), other, track)
+}
+
+// mergeInto stores the values of c and then those of other in newCtx.
+func (c *ContainerContext) mergeInto(newCtx *ContainerContext, other *ContainerContext, track bool) (*ContainerContext, []string) {
 	// First copy values from current context (base values)
-	c.values.Range(func(k, v interface{}) bool {
-		newCtx.values.Store(k, v)
-		return true
-	})
+	c.copyInto(newCtx)
 
 	// Then copy values from the other context (overriding values)
+	var overridden []string
 	if other != nil {
 		other.values.Range(func(k, v interface{}) bool {
-			newCtx.values.Store(k, v)
+			if _, loaded := newCtx.values.Swap(k, v); loaded && track {
+				overridden = append(overridden, fmt.Sprint(k))
+			}
 			return true
 		})
 	}
+	sort.Strings(overridden)
 
-	return newCtx
+	return newCtx, overridden
+}
+
+// ValueAs returns the value stored under key in ctx as a T. The boolean is
+// false if there is no value or it is not a T.
+func ValueAs[T any](ctx *ContainerContext, key interface{}) (T, bool) {
+	typed, ok := ctx.Value(key).(T)
+	return typed, ok
+}
+
+// StringValue returns the string stored under key, reporting false if there
+// is no value or it is not a string.
+func (c *ContainerContext) StringValue(key interface{}) (string, bool) {
+	return ValueAs[string](c, key)
+}
+
+// IntValue returns the int stored under key, reporting false if there is no
+// value or it is not an int.
+func (c *ContainerContext) IntValue(key interface{}) (int, bool) {
+	return ValueAs[int](c, key)
+}
+
+// BoolValue returns the bool stored under key, reporting false if there is no
+// value or it is not a bool.
+func (c *ContainerContext) BoolValue(key interface{}) (bool, bool) {
+	return ValueAs[bool](c, key)
+}
+
+// ResolutionDepth returns the nesting depth recorded under ResolutionDepthKey.
+// It returns 0 when the context was not passed to OnBoot by a resolution.
+func (c *ContainerContext) ResolutionDepth() int {
+	if depth, ok := c.Value(ResolutionDepthKey).(int); ok {
+		return depth
+	}
+	return 0
+}
+
+// withCancellation returns a scratch copy of the context whose deadline and
+// cancellation come from cancel while values are still looked up in the
+// original context.
+func (c *ContainerContext) withCancellation(cancel context.Context) *ContainerContext {
+	return c.copyInto(acquireContext(boundedContext{Context: cancel, values: c.Context}))
+}
+
+// boundedContext takes cancellation from its embedded context and values from another.
+type boundedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (b boundedContext) Value(key interface{}) interface{} {
+	if b.values == nil {
+		return nil
+	}
+	return b.values.Value(key)
 }
@@ -0,0 +1,50 @@
+package digo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ContextPoolTestSuite struct {
+	suite.Suite
+}
+
+func (s *ContextPoolTestSuite) TestReleaseResetsScratchContexts() {
+	ctx := acquireContext(context.Background())
+	ctx.values.Store("request_id", "req-1")
+
+	releaseContext(ctx)
+	s.False(ctx.pooled)
+	s.Nil(ctx.Context)
+	s.Nil(ctx.Value("request_id"), "Released contexts should not keep their values")
+
+	releaseContext(ctx)
+	s.False(ctx.pooled, "Releasing twice should be a no-op")
+}
+
+func (s *ContextPoolTestSuite) TestReleaseIgnoresPublishedContexts() {
+	ctx := NewContainerContext(context.Background()).WithValue("env", "prod")
+	releaseContext(ctx)
+	s.Equal("prod", ctx.Value("env"), "Contexts not taken from the pool should be left untouched")
+	s.NotNil(ctx.Context)
+}
+
+func (s *ContextPoolTestSuite) TestBootContextReleasesScratch() {
+	c := newContainer()
+	caller := NewContainerContext(context.Background()).WithValue("request_id", "req-1")
+	scratch, _ := NewContainerContext(context.Background()).WithValue("env", "prod").mergeInto(acquireContext(context.Background()), caller, false)
+
+	bootCtx := c.bootContext(makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem()), scratch)
+	s.False(scratch.pooled, "The scratch context should be released")
+	s.False(bootCtx.pooled, "The boot context escapes to OnBoot and must not be pooled")
+	s.Equal("prod", bootCtx.Value("env"))
+	s.Equal("req-1", bootCtx.Value("request_id"))
+	s.Equal(0, bootCtx.ResolutionDepth())
+}
+
+func TestContextPoolSuite(t *testing.T) {
+	suite.Run(t, new(ContextPoolTestSuite))
+}
@@ -0,0 +1,75 @@
+package digo
+
+import (
+	"reflect"
+	"sort"
+)
+
+// DependsOn declares that T depends on D, for services whose dependencies are
+// resolved in OnBoot and so cannot be inspected. Declarations are checked by
+// DetectCycles and cleared by Reset; they do not affect resolution.
+func DependsOn[T Lifecycle, D Lifecycle]() {
+	instance := GetContainer()
+	from := reflect.TypeOf((*T)(nil)).Elem()
+	to := reflect.TypeOf((*D)(nil)).Elem()
+
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+	if instance.dependsOn == nil {
+		instance.dependsOn = make(map[reflect.Type][]reflect.Type)
+	}
+	for _, existing := range instance.dependsOn[from] {
+		if existing == to {
+			return
+		}
+	}
+	instance.dependsOn[from] = append(instance.dependsOn[from], to)
+}
+
+// DetectCycles returns every cycle in the dependencies declared with DependsOn,
+// without resolving anything. Each cycle lists its types in dependency order,
+// starting from the type that sorts first, so A -> B -> A is reported once as
+// [A B]. Cycles are ordered by their first type.
+func DetectCycles() [][]string {
+	instance := GetContainer()
+	instance.mu.RLock()
+	edges := make(map[string][]string, len(instance.dependsOn))
+	for from, deps := range instance.dependsOn {
+		for _, to := range deps {
+			edges[from.String()] = append(edges[from.String()], to.String())
+		}
+	}
+	instance.mu.RUnlock()
+
+	nodes := make([]string, 0, len(edges))
+	for node, deps := range edges {
+		nodes = append(nodes, node)
+		sort.Strings(deps)
+	}
+	sort.Strings(nodes)
+
+	// Each elementary cycle is found once, from its smallest type, by only
+	// walking through types that sort after the start
+	var cycles [][]string
+	for _, start := range nodes {
+		onPath := map[string]bool{start: true}
+		path := []string{start}
+		var walk func(node string)
+		walk = func(node string) {
+			for _, next := range edges[node] {
+				switch {
+				case next == start:
+					cycles = append(cycles, append([]string(nil), path...))
+				case next > start && !onPath[next]:
+					onPath[next] = true
+					path = append(path, next)
+					walk(next)
+					path = path[:len(path)-1]
+					onPath[next] = false
+				}
+			}
+		}
+		walk(start)
+	}
+	return cycles
+}
@@ -0,0 +1,91 @@
+// Package digotest provides assertion helpers for tests that use digo.
+// Each helper marks itself with t.Helper and calls t.Fatal on failure.
+package digotest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/centraunit/digo"
+)
+
+// RequireBound fails the test unless T is registered with the given scope.
+// The service is not initialized.
+func RequireBound[T digo.Lifecycle](t testing.TB, scope digo.Scope) {
+	t.Helper()
+	if !digo.IsBound[T](scope) {
+		t.Fatalf("digotest: %s is not bound with %s scope", typeName[T](), scope)
+	}
+}
+
+// RequireResolves resolves T with the given scope and returns it.
+// It fails the test if resolution returns an error.
+func RequireResolves[T digo.Lifecycle](t testing.TB, scope digo.Scope) T {
+	t.Helper()
+	service, err := digo.ResolveNamed[T](scope, "")
+	if err != nil {
+		t.Fatalf("digotest: resolving %s with %s scope: %v", typeName[T](), scope, err)
+	}
+	return service
+}
+
+// AssertContextValuePropagates binds a probe service with the given scope and an
+// empty binding context, resolves it, and fails the test unless the context its
+// OnBoot receives carries expected under key. The value must therefore come from
+// the container, such as a base value or the request begun with BeginRequest.
+func AssertContextValuePropagates(t testing.TB, scope digo.Scope, key string, expected interface{}) {
+	t.Helper()
+	captured := &probeCapture{}
+	probe := &contextProbe{captured: captured}
+	ctx := digo.NewContainerContext(context.Background())
+
+	var err error
+	switch scope {
+	case digo.ScopeSingleton:
+		err = digo.Bind(probe).WithContext(ctx).AsSingleton()
+	case digo.ScopeTransient:
+		err = digo.Bind(probe).WithContext(ctx).AsTransient()
+	case digo.ScopeRequest:
+		err = digo.Bind(probe).WithContext(ctx).AsRequest()
+	default:
+		t.Fatalf("digotest: context propagation is not supported with %s scope", scope)
+		return
+	}
+	if err != nil {
+		t.Fatalf("digotest: binding context probe with %s scope: %v", scope, err)
+		return
+	}
+	if _, err := digo.ResolveNamed[*contextProbe](scope, ""); err != nil {
+		t.Fatalf("digotest: resolving context probe with %s scope: %v", scope, err)
+		return
+	}
+	if captured.ctx == nil {
+		t.Fatalf("digotest: context probe with %s scope was not booted", scope)
+		return
+	}
+	if actual := captured.ctx.Value(key); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("digotest: context value %q in OnBoot with %s scope is %v, expected %v", key, scope, actual, expected)
+	}
+}
+
+// probeCapture is shared by a contextProbe and the copies request scope makes of it.
+type probeCapture struct {
+	ctx *digo.ContainerContext
+}
+
+// contextProbe records the context of its OnBoot.
+type contextProbe struct {
+	captured *probeCapture
+}
+
+func (p *contextProbe) OnBoot(ctx *digo.ContainerContext) error {
+	p.captured.ctx = ctx
+	return nil
+}
+
+func (p *contextProbe) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
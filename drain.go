@@ -0,0 +1,36 @@
+package digo
+
+import (
+	"context"
+	"time"
+)
+
+// drainPollInterval is how often Drain checks for in-flight resolutions.
+const drainPollInterval = time.Millisecond
+
+// Drain shuts the container down in an orderly way. It stops accepting new
+// resolutions, which fail with ContainerClosingError, waits for in-flight
+// resolutions to complete and then shuts down every service in reverse
+// registration order, as Shutdown(true) does.
+// Returns ctx.Err() if ctx ends before in-flight resolutions complete; the
+// container keeps rejecting resolutions until Reset. Returns
+// DrainInResolutionError, without closing the container, if called from inside
+// a resolution such as an OnBoot.
+func Drain(ctx context.Context) error {
+	instance := GetContainer()
+	if _, ok := instance.resolutionState.Load(instance.getGoroutineID()); ok {
+		return instance.wrapError(&DrainInResolutionError{})
+	}
+	instance.closing.Store(true)
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for ResolutionStateCount() > 0 {
+		select {
+		case <-ctx.Done():
+			return instance.wrapError(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return instance.wrapError(instance.shutdown(true))
+}
@@ -0,0 +1,89 @@
+package digo
+
+import "sync"
+
+// endedRequestLimit bounds how many ended requests are remembered.
+const endedRequestLimit = 1024
+
+// endedRequests remembers the most recently ended requests and the goroutines
+// that were associated with them, so a late resolution can report
+// RequestEndedError rather than BindingNotFoundError.
+type endedRequests struct {
+	mu          sync.Mutex
+	order       []string
+	goroutines  map[string][]string
+	byGoroutine map[string]string
+}
+
+// add records that requestID ended while associated with goroutines.
+func (e *endedRequests) add(requestID string, goroutines []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.goroutines == nil {
+		e.goroutines = make(map[string][]string)
+		e.byGoroutine = make(map[string]string)
+	}
+	if _, ok := e.goroutines[requestID]; !ok {
+		e.order = append(e.order, requestID)
+	}
+	e.goroutines[requestID] = append(e.goroutines[requestID], goroutines...)
+	for _, id := range goroutines {
+		e.byGoroutine[id] = requestID
+	}
+	for len(e.order) > endedRequestLimit {
+		e.remove(e.order[0])
+	}
+}
+
+// forget drops requestID, which is live again. Callers must not hold e.mu.
+func (e *endedRequests) forget(requestID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.remove(requestID)
+}
+
+// remove drops requestID and its goroutines. Callers must hold e.mu.
+func (e *endedRequests) remove(requestID string) {
+	goroutines, ok := e.goroutines[requestID]
+	if !ok {
+		return
+	}
+	for _, id := range goroutines {
+		if e.byGoroutine[id] == requestID {
+			delete(e.byGoroutine, id)
+		}
+	}
+	delete(e.goroutines, requestID)
+	for i, ended := range e.order {
+		if ended == requestID {
+			e.order = append(e.order[:i], e.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// lookup returns the ended request a resolution of key on goroutine targets,
+// or "" if it does not target an ended request.
+func (e *endedRequests) lookup(key bindingKey, goroutine string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	requestID := key.request
+	if requestID == "" {
+		requestID = e.byGoroutine[goroutine]
+	}
+	if _, ok := e.goroutines[requestID]; ok {
+		return requestID
+	}
+	return ""
+}
+
+// reset forgets every ended request.
+func (e *endedRequests) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.order = nil
+	e.goroutines = nil
+	e.byGoroutine = nil
+}
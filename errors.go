@@ -1,6 +1,10 @@
 package digo
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // CircularDependencyError represents a circular dependency detection error.
 type CircularDependencyError struct {
@@ -14,12 +18,151 @@ func (e *CircularDependencyError) Error() string {
 // BindingNotFoundError represents a missing binding error.
 type BindingNotFoundError struct {
 	Type string
+	// Request is the request the lookup was scoped to, for request scope
+	Request string
 }
 
 func (e *BindingNotFoundError) Error() string {
+	if e.Request != "" {
+		return fmt.Sprintf("no binding found for type: %s in request %s", e.Type, e.Request)
+	}
 	return fmt.Sprintf("no binding found for type: %s", e.Type)
 }
 
+// ScopeMismatchError represents a resolution in a scope other than the one the type is bound in.
+type ScopeMismatchError struct {
+	Type           string
+	BoundScope     Scope
+	RequestedScope Scope
+}
+
+func (e *ScopeMismatchError) Error() string {
+	return fmt.Sprintf("type %s is bound with %s scope but was resolved with %s scope", e.Type, e.BoundScope, e.RequestedScope)
+}
+
+// InstantiationMismatchError represents a resolution of a generic type whose
+// generic type is bound only under a different instantiation, such as
+// resolving Repo[B] when Repo[A] is bound.
+type InstantiationMismatchError struct {
+	Requested string
+	Bound     string
+}
+
+func (e *InstantiationMismatchError) Error() string {
+	return fmt.Sprintf("no binding found for type %s, but %s is bound: instantiations of a generic type are distinct types", e.Requested, e.Bound)
+}
+
+// ContainerClosingError represents a resolution attempted while the container is draining.
+type ContainerClosingError struct {
+	Type string
+}
+
+func (e *ContainerClosingError) Error() string {
+	return fmt.Sprintf("container is closing, cannot resolve type: %s", e.Type)
+}
+
+// DrainInResolutionError represents a Drain called from inside a resolution,
+// which would wait for itself to complete.
+type DrainInResolutionError struct{}
+
+func (e *DrainInResolutionError) Error() string {
+	return "cannot drain the container from inside a resolution"
+}
+
+// ArgumentsNotSupportedError represents resolution arguments passed to a binding without a factory.
+type ArgumentsNotSupportedError struct {
+	Type string
+}
+
+func (e *ArgumentsNotSupportedError) Error() string {
+	return fmt.Sprintf("binding for type %s does not accept arguments, register it with ProvideTransient", e.Type)
+}
+
+// FactoryRequiredError represents a transient resolution of a binding without a
+// factory while the NewViaFactory strategy is in effect.
+type FactoryRequiredError struct {
+	Type string
+}
+
+func (e *FactoryRequiredError) Error() string {
+	return fmt.Sprintf("transient binding for type %s has no factory, register it with ProvideTransient", e.Type)
+}
+
+// BindAfterBootError represents a bind attempted on a booted container sealed with SetSealAfterBoot.
+type BindAfterBootError struct {
+	Type string
+}
+
+func (e *BindAfterBootError) Error() string {
+	return fmt.Sprintf("cannot bind type %s: container is sealed after boot", e.Type)
+}
+
+// ContainerFrozenError represents a change to the bindings while the container is frozen.
+type ContainerFrozenError struct {
+	Op string
+}
+
+func (e *ContainerFrozenError) Error() string {
+	return fmt.Sprintf("cannot %s: container is frozen", e.Op)
+}
+
+// BindSpecError represents a failure to register one of the specs passed to BindAll.
+type BindSpecError struct {
+	Index int
+	Err   error
+}
+
+func (e *BindSpecError) Error() string {
+	return fmt.Sprintf("bind spec %d: %v", e.Index, e.Err)
+}
+
+func (e *BindSpecError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateBindingError represents a binding that already exists in the target container.
+type DuplicateBindingError struct {
+	Key string
+}
+
+func (e *DuplicateBindingError) Error() string {
+	return fmt.Sprintf("binding already exists: %s", e.Key)
+}
+
+// InvalidTagError represents a service passed to AutoBind without a usable digo tag.
+// Tag is empty when the service has no AutoBinding field.
+type InvalidTagError struct {
+	Type string
+	Tag  string
+}
+
+func (e *InvalidTagError) Error() string {
+	if e.Tag == "" {
+		return fmt.Sprintf("no digo binding tag found for type: %s", e.Type)
+	}
+	return fmt.Sprintf("invalid digo tag %q for type %s", e.Tag, e.Type)
+}
+
+// InvalidTargetError represents a resolution target that is not a non-nil pointer.
+type InvalidTargetError struct {
+	Type string
+}
+
+func (e *InvalidTargetError) Error() string {
+	return fmt.Sprintf("invalid resolution target of type %s: must be a non-nil pointer", e.Type)
+}
+
+// InvalidConstructError represents a Construct method the container cannot call.
+// Construct may take any bound types and must return nothing or a single error.
+type InvalidConstructError struct {
+	Type      string
+	Signature string
+}
+
+func (e *InvalidConstructError) Error() string {
+	return fmt.Sprintf("invalid Construct method %s for type %s: must return nothing or error", e.Signature, e.Type)
+}
+
 // NilServiceError represents an attempt to bind a nil service.
 type NilServiceError struct {
 	Type string
@@ -30,9 +173,12 @@ func (e *NilServiceError) Error() string {
 }
 
 // InitializationError represents a service initialization failure.
+// Chain lists the binding keys that were being resolved when it occurred,
+// from the outermost resolution to the failing service.
 type InitializationError struct {
-	Type string
-	Err  error
+	Type  string
+	Err   error
+	Chain []string
 }
 
 func (e *InitializationError) Error() string {
@@ -43,6 +189,39 @@ func (e *InitializationError) Unwrap() error {
 	return e.Err
 }
 
+// MultiBootError aggregates the failures of BootAll, one per service.
+type MultiBootError struct {
+	Errors []error
+}
+
+func (e *MultiBootError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d services failed to boot: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *MultiBootError) Unwrap() []error {
+	return e.Errors
+}
+
+// FailedChain returns the resolution chain of the innermost InitializationError
+// in err, pinpointing where a nested boot failed. It returns nil if err
+// contains no InitializationError.
+func FailedChain(err error) []string {
+	var chain []string
+	for err != nil {
+		var initErr *InitializationError
+		if !errors.As(err, &initErr) {
+			break
+		}
+		chain = initErr.Chain
+		err = initErr.Err
+	}
+	return chain
+}
+
 // MissingContextValueError represents a missing required context value.
 type MissingContextValueError struct {
 	Key string
@@ -52,6 +231,26 @@ func (e *MissingContextValueError) Error() string {
 	return fmt.Sprintf("required context value not found: %s", e.Key)
 }
 
+// RequestEndedError represents a resolution targeting a request that EndRequest has already ended.
+type RequestEndedError struct {
+	RequestID string
+}
+
+func (e *RequestEndedError) Error() string {
+	return fmt.Sprintf("request %s has ended", e.RequestID)
+}
+
+// InvalidContextValueError represents a context value of an unexpected type.
+type InvalidContextValueError struct {
+	Key      string
+	Expected string
+	Got      string
+}
+
+func (e *InvalidContextValueError) Error() string {
+	return fmt.Sprintf("context value %s has type %s, expected %s", e.Key, e.Got, e.Expected)
+}
+
 // TypeMismatchError represents a type assertion failure.
 type TypeMismatchError struct {
 	Expected string
@@ -113,3 +312,35 @@ type InvalidScopeError struct {
 func (e *InvalidScopeError) Error() string {
 	return fmt.Sprintf("invalid scope %s for type %s", e.Scope, e.Type)
 }
+
+// NoMatchingBindingError represents a conditional binding where no candidate applied.
+type NoMatchingBindingError struct {
+	Type string
+}
+
+func (e *NoMatchingBindingError) Error() string {
+	return fmt.Sprintf("no conditional binding matched for type: %s", e.Type)
+}
+
+// NotCloneableError represents a prototype that cannot be copied per resolution.
+type NotCloneableError struct {
+	Type string
+}
+
+func (e *NotCloneableError) Error() string {
+	return fmt.Sprintf("service of type %s cannot be cloned: expected a pointer to a struct", e.Type)
+}
+
+// TimeoutError represents a resolution that did not complete within its deadline.
+type TimeoutError struct {
+	Type string
+	Err  error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("resolution timed out for type %s: %v", e.Type, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,33 @@
+package digo
+
+// SetErrorWrapper installs fn to wrap every error returned by the public
+// Resolve, Boot and Shutdown functions, typically to attach a trace ID.
+// fn should keep the original error reachable through Unwrap so that errors.As
+// still matches the container's error types. Passing nil removes it. The
+// returned function reinstates the wrapper installed before.
+func SetErrorWrapper(fn func(err error) error) (restore func()) {
+	instance := GetContainer()
+	var wrapper *func(err error) error
+	if fn != nil {
+		wrapper = &fn
+	}
+	previous := instance.errorWrapper.Swap(wrapper)
+	return func() { instance.errorWrapper.Store(previous) }
+}
+
+// wrapError applies the installed error wrapper to err. A nil err stays nil.
+func (c *container) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	wrapper := c.errorWrapper.Load()
+	if wrapper == nil {
+		return err
+	}
+	return (*wrapper)(err)
+}
+
+// wrapResult applies the error wrapper to the error of a resolution result.
+func wrapResult[T any](service T, err error) (T, error) {
+	return service, GetContainer().wrapError(err)
+}
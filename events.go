@@ -0,0 +1,109 @@
+package digo
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind classifies a container event.
+type EventKind string
+
+// Recorded container events
+const (
+	// EventBind is recorded when a binding is registered
+	EventBind EventKind = "bind"
+	// EventBoot is recorded when a service's OnBoot succeeds
+	EventBoot EventKind = "boot"
+	// EventResolve is recorded when a resolution succeeds
+	EventResolve EventKind = "resolve"
+	// EventShutdown is recorded when a service's OnShutdown succeeds
+	EventShutdown EventKind = "shutdown"
+	// EventError is recorded when a resolution, boot or shutdown fails
+	EventError EventKind = "error"
+)
+
+// Event describes a single container operation retained by the event log.
+type Event struct {
+	Time  time.Time
+	Kind  EventKind
+	Type  string
+	Scope Scope
+	Err   error
+}
+
+// eventLog is a fixed-size ring buffer of recent events.
+type eventLog struct {
+	size   atomic.Int64
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// SetEventLogSize sets how many recent events the container retains.
+// A size of zero disables the event log. Changing the size discards retained events.
+func SetEventLogSize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	log := &GetContainer().events
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
+	log.size.Store(int64(n))
+	log.events = make([]Event, n)
+	log.next = 0
+	log.full = false
+}
+
+// RecentEvents returns the retained events, oldest first.
+func RecentEvents() []Event {
+	log := &GetContainer().events
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
+	if !log.full {
+		return append([]Event(nil), log.events[:log.next]...)
+	}
+	events := make([]Event, 0, len(log.events))
+	events = append(events, log.events[log.next:]...)
+	return append(events, log.events[:log.next]...)
+}
+
+// recordEvent appends an event for key to the log if it is enabled and passes
+// it to the registered observers.
+// A non-nil err turns the event into an EventError.
+func (c *container) recordEvent(kind EventKind, key bindingKey, err error) {
+	c.stats.count(kind, err)
+	if err == nil {
+		c.recordTransition(kind, key)
+	}
+	log := &c.events
+	observed := c.observers.active()
+	if log.size.Load() == 0 && !observed {
+		return
+	}
+	if err != nil {
+		kind = EventError
+	}
+	event := Event{Time: time.Now(), Kind: kind, Scope: key.scope, Err: err}
+	if key.typ != nil {
+		event.Type = key.typ.String()
+	}
+	if observed {
+		c.observers.notify(event)
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if len(log.events) == 0 {
+		return
+	}
+	log.events[log.next] = event
+	log.next++
+	if log.next == len(log.events) {
+		log.next = 0
+		log.full = true
+	}
+}
@@ -15,3 +15,25 @@ func goid() int64 {
 	id, _ := strconv.ParseInt(idField, 10, 64)
 	return id
 }
+
+// goroutineIdentifier reports the identity of the calling goroutine.
+// Resolution state is tracked per identity, so tests can substitute a
+// deterministic implementation to exercise that logic without real goroutines.
+type goroutineIdentifier interface {
+	ID() int64
+}
+
+// runtimeGoroutines identifies goroutines by their runtime ID.
+type runtimeGoroutines struct{}
+
+func (runtimeGoroutines) ID() int64 {
+	return goid()
+}
+
+// setGoroutineIdentifier replaces the goroutine identity source.
+// It is intended for tests only and must not be called while resolutions are in flight.
+func (c *container) setGoroutineIdentifier(identifier goroutineIdentifier) {
+	c.resolutionMu.Lock()
+	defer c.resolutionMu.Unlock()
+	c.goroutines = identifier
+}
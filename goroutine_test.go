@@ -0,0 +1,133 @@
+package digo
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fixedGoroutine reports the same identity for every caller
+type fixedGoroutine int64
+
+func (f fixedGoroutine) ID() int64 {
+	return int64(f)
+}
+
+type ResolutionStateTestSuite struct {
+	suite.Suite
+	c *container
+}
+
+func (s *ResolutionStateTestSuite) SetupTest() {
+	s.c = newContainer()
+	s.c.setGoroutineIdentifier(fixedGoroutine(7))
+}
+
+func (s *ResolutionStateTestSuite) activeStates() int {
+	count := 0
+	s.c.resolutionState.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func (s *ResolutionStateTestSuite) TestCircularDetection() {
+	outer := makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+	inner := makeBindingKey(ScopeSingleton, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+
+	s.NoError(s.c.startResolving(outer))
+	s.NoError(s.c.startResolving(inner))
+
+	err := s.c.startResolving(outer)
+	var circularErr *CircularDependencyError
+	s.ErrorAs(err, &circularErr)
+	s.Equal(outer.String(), circularErr.Type)
+
+	s.c.finishResolving(inner)
+	s.c.finishResolving(outer)
+	s.Equal(0, s.activeStates())
+}
+
+func (s *ResolutionStateTestSuite) TestIdentitiesAreIsolated() {
+	key := makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+
+	s.NoError(s.c.startResolving(key))
+	s.c.setGoroutineIdentifier(fixedGoroutine(8))
+	s.NoError(s.c.startResolving(key), "Another goroutine may resolve the same key")
+	s.Equal(2, s.activeStates())
+
+	s.c.finishResolving(key)
+	s.c.setGoroutineIdentifier(fixedGoroutine(7))
+	s.c.finishResolving(key)
+	s.Equal(0, s.activeStates())
+}
+
+func (s *ResolutionStateTestSuite) TestSharedIdentityAcrossGoroutines() {
+	key := makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+	s.NoError(s.c.startResolving(key))
+
+	// With a pinned identity a different goroutine observes the same chain
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := s.c.startResolving(key)
+		var circularErr *CircularDependencyError
+		s.ErrorAs(err, &circularErr)
+	}()
+	wg.Wait()
+
+	s.c.finishResolving(key)
+	s.Equal(0, s.activeStates())
+}
+
+func (s *ResolutionStateTestSuite) TestEmptyChainResetsState() {
+	outer := makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+	inner := makeBindingKey(ScopeSingleton, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+
+	s.NoError(s.c.startResolving(outer))
+	s.NoError(s.c.startResolving(inner))
+	state := s.c.getResolutionState()
+	state.deadline = context.Background()
+	state.contexts = map[bindingKey]*ContainerContext{outer: NewContainerContext(context.Background())}
+
+	// Finishing out of order keeps the state until the chain empties
+	s.c.finishResolving(outer)
+	s.Equal(1, s.activeStates())
+	s.Same(state, s.c.getResolutionState())
+	s.Equal([]bindingKey{inner}, state.keyCache)
+
+	s.c.finishResolving(inner)
+	s.Equal(0, s.activeStates(), "An empty chain should return the state to the pool")
+	s.Empty(state.chain)
+	s.Empty(state.keyCache)
+	s.Nil(state.deadline)
+	s.Nil(state.contexts)
+
+	// A state taken from the pool starts a fresh chain
+	s.NoError(s.c.startResolving(outer), "A released state should not report a stale cycle")
+	s.c.finishResolving(outer)
+	s.Equal(0, s.activeStates())
+}
+
+func (s *ResolutionStateTestSuite) TestPinnedStateSurvivesEmptyChain() {
+	key := makeBindingKey(ScopeTransient, reflect.TypeOf((*Lifecycle)(nil)).Elem())
+
+	state := s.c.pinState()
+	s.NoError(s.c.startResolving(key))
+	s.c.finishResolving(key)
+	s.Equal(1, s.activeStates(), "A pinned state should outlive its chain")
+	s.Same(state, s.c.getResolutionState())
+
+	s.c.unpinState(state)
+	s.Equal(0, s.activeStates())
+	s.Zero(state.holds)
+}
+
+func TestResolutionStateSuite(t *testing.T) {
+	suite.Run(t, new(ResolutionStateTestSuite))
+}
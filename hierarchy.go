@@ -0,0 +1,75 @@
+package digo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// standIns maps goroutines resolving through a parent to that parent
+	standIns   sync.Map
+	standingIn atomic.Int32
+)
+
+// NewChildContainer creates an empty container whose resolutions fall back to
+// parent when a binding is missing locally. Services resolved through the
+// fallback stay owned by the container that binds them, so a parent singleton
+// is shared by the parent and all of its children, while a child can override
+// any parent binding by binding the same type itself. A service the parent owns
+// is booted against the parent: dependencies its OnBoot resolves through the
+// package-level API come from the parent, not from the child's overrides.
+func NewChildContainer(parent *container) *container {
+	c := newContainer()
+	c.parent = parent
+	return c
+}
+
+// UseContainer installs c as the container used by the package-level API and
+// returns the container it replaces, so callers can restore it when done.
+// A nil c leaves the current container in place.
+func UseContainer(c *container) *container {
+	previous := GetContainer()
+	if c == nil {
+		return previous
+	}
+	return activeContainer.Swap(c)
+}
+
+// parentOwner returns the nearest ancestor of c that binds key, or nil if no
+// ancestor does. Request keys are matched against each ancestor's own requests.
+func (c *container) parentOwner(key bindingKey) *container {
+	for p := c.parent; p != nil; p = p.parent {
+		lookup := key
+		if key.scope == ScopeRequest {
+			lookup = p.currentRequestKey(key)
+		}
+		if _, ok := p.getBinding(lookup); ok {
+			return p
+		}
+		if key.scope == ScopeRequest && p.activeRequest() != nil {
+			if _, ok := p.getBinding(bindingKey{scope: key.scope, typ: key.typ, name: key.name}); ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// standIn runs resolve with c as the container of the calling goroutine, so
+// the package-level API used by OnBoot of a service c owns resolves from c's
+// bindings rather than from the overrides of the child that delegated to it.
+func (c *container) standIn(resolve func()) {
+	id := goid()
+	previous, nested := standIns.Load(id)
+	standIns.Store(id, c)
+	standingIn.Add(1)
+	defer func() {
+		standingIn.Add(-1)
+		if nested {
+			standIns.Store(id, previous)
+		} else {
+			standIns.Delete(id)
+		}
+	}()
+	resolve()
+}
@@ -0,0 +1,79 @@
+package digo
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// StateTransition is a change in a binding's initialization state: EventBind
+// when it is registered, EventBoot when OnBoot succeeds and EventShutdown when
+// OnShutdown succeeds.
+type StateTransition struct {
+	Kind EventKind
+	Time time.Time
+}
+
+// bindingHistory holds the retained transitions of a single binding.
+type bindingHistory struct {
+	mu          sync.Mutex
+	transitions []StateTransition
+}
+
+// SetHistoryLimit enables per-binding state histories for debugging, keeping
+// the n most recent transitions of each binding. A limit of zero, the default,
+// disables recording. Changing the limit discards retained histories.
+func SetHistoryLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	instance := GetContainer()
+	instance.historyLimit.Store(int64(n))
+	instance.histories.Range(func(key, _ any) bool {
+		instance.histories.Delete(key)
+		return true
+	})
+}
+
+// BindingHistory returns the retained state transitions of T in the given
+// scope, oldest first. Histories outlive Reset, so a binding that is reset and
+// bound again keeps a single timeline. Likewise the bindings of T in every
+// request or session share one timeline.
+func BindingHistory[T Lifecycle](scope Scope) []StateTransition {
+	instance := GetContainer()
+	key := makeBindingKey(scope, reflect.TypeOf((*T)(nil)).Elem())
+	value, ok := instance.histories.Load(key)
+	if !ok {
+		return nil
+	}
+	history := value.(*bindingHistory)
+	history.mu.Lock()
+	defer history.mu.Unlock()
+	return append([]StateTransition(nil), history.transitions...)
+}
+
+// recordTransition appends a transition to the history of key if histories
+// are enabled. Only bind, boot and shutdown events change a binding's state.
+// Histories are kept per binding rather than per request or session, so they
+// stay bounded however many requests come and go.
+func (c *container) recordTransition(kind EventKind, key bindingKey) {
+	limit := int(c.historyLimit.Load())
+	if limit == 0 || key.typ == nil {
+		return
+	}
+	switch kind {
+	case EventBind, EventBoot, EventShutdown:
+	default:
+		return
+	}
+
+	key.request = ""
+	value, _ := c.histories.LoadOrStore(key, &bindingHistory{})
+	history := value.(*bindingHistory)
+	history.mu.Lock()
+	defer history.mu.Unlock()
+	history.transitions = append(history.transitions, StateTransition{Kind: kind, Time: time.Now()})
+	if excess := len(history.transitions) - limit; excess > 0 {
+		history.transitions = append(history.transitions[:0], history.transitions[excess:]...)
+	}
+}
@@ -0,0 +1,41 @@
+package digo
+
+// shutdownHookType names shutdown hooks in ShutdownError.
+const shutdownHookType = "shutdown hook"
+
+// OnShutdownHook registers fn to run during the next Shutdown, after every
+// service has been shut down. Hooks run in reverse registration order with the
+// container's base context, and each runs once: a hook that succeeds is
+// removed, while a failing hook stops Shutdown and is kept for a retry.
+// Hooks run without the container lock held, so they may use the container.
+// Reset discards pending hooks without running them.
+func OnShutdownHook(fn func(ctx *ContainerContext) error) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.shutdownHooks = append(instance.shutdownHooks, fn)
+	instance.mu.Unlock()
+}
+
+// runShutdownHooks runs the pending shutdown hooks, newest first, releasing
+// c.mu while they run so a hook can call back into the container. Hooks that
+// did not run are kept, before any registered meanwhile.
+// Returns ShutdownError for the first hook that fails.
+// Callers must hold c.mu.
+func (c *container) runShutdownHooks() error {
+	hooks, ctx := c.shutdownHooks, c.ctx
+	c.shutdownHooks = nil
+	c.mu.Unlock()
+	var err error
+	for len(hooks) > 0 {
+		last := len(hooks) - 1
+		if err = hooks[last](ctx); err != nil {
+			err = &ShutdownError{Type: shutdownHookType, Err: err}
+			break
+		}
+		hooks[last] = nil
+		hooks = hooks[:last]
+	}
+	c.mu.Lock()
+	c.shutdownHooks = append(hooks, c.shutdownHooks...)
+	return err
+}
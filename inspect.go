@@ -0,0 +1,122 @@
+package digo
+
+import (
+	"reflect"
+	"sort"
+)
+
+// BindingInfo describes a registered binding for introspection.
+type BindingInfo struct {
+	// Type is the bound type, e.g. "mock.Database".
+	Type  string
+	Scope Scope
+	// Name is the name given with Named, or "" for the default binding.
+	Name string
+	// Request is the request_id of a request binding, or "" for a template, and
+	// the session_id of a session binding.
+	Request string
+	Tags    []string
+	// Initialized reports whether the service has been booted.
+	Initialized bool
+	// Conditional reports whether the service is selected by a predicate or
+	// chained candidates at resolution time.
+	Conditional bool
+}
+
+// ListBindings returns every registered binding in registration order.
+// It never initializes a service.
+func ListBindings() []BindingInfo {
+	instance := GetContainer()
+	instance.mu.RLock()
+	bindings := instance.snapshot()
+	instance.mu.RUnlock()
+
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].id < bindings[j].id })
+	infos := make([]BindingInfo, 0, len(bindings))
+	for _, binding := range bindings {
+		infos = append(infos, BindingInfo{
+			Type:        binding.key.typ.String(),
+			Scope:       binding.scope,
+			Name:        binding.key.name,
+			Request:     binding.key.request,
+			Tags:        append([]string(nil), binding.tags...),
+			Initialized: binding.initialized,
+			Conditional: binding.hasCondition(),
+		})
+	}
+	return infos
+}
+
+// PendingSingletons returns the singletons that have not been booted yet, such
+// as lazy bindings nothing has resolved or services a failed Boot skipped, in
+// registration order. Each entry is the bound type, followed by "#name" for a
+// named binding. It never initializes a service.
+func PendingSingletons() []string {
+	instance := GetContainer()
+	instance.mu.RLock()
+	bindings := instance.snapshot()
+	instance.mu.RUnlock()
+
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].id < bindings[j].id })
+	var pending []string
+	for _, binding := range bindings {
+		if binding.scope != ScopeSingleton || binding.initialized || binding.isAlias() || binding.owner != nil {
+			continue
+		}
+		pending = append(pending, singletonName(binding.key))
+	}
+	return pending
+}
+
+// singletonName renders a singleton key as its bound type, followed by "#name"
+// for a named binding.
+func singletonName(key bindingKey) string {
+	name := key.typ.String()
+	if key.name != "" {
+		name += "#" + key.name
+	}
+	return name
+}
+
+// HasPredicate reports whether T is bound with the given scope and selected by
+// a predicate or chained candidates. It returns false if T is not bound.
+func HasPredicate[T Lifecycle](scope Scope) bool {
+	instance := GetContainer()
+	key := makeBindingKey(scope, reflect.TypeOf((*T)(nil)).Elem())
+	if scope == ScopeRequest {
+		key = instance.currentRequestKey(key)
+	}
+
+	instance.mu.RLock()
+	defer instance.mu.RUnlock()
+	binding, ok := instance.bindings.Get(key)
+	return ok && binding.hasCondition()
+}
+
+// BoundScope reports the scope T is bound with, whatever its name. If T is
+// bound with several scopes, the earliest registered binding wins. Bindings of
+// a parent container are consulted when T is not bound in the container itself.
+func BoundScope[T Lifecycle]() (Scope, bool) {
+	return GetContainer().boundScope(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// boundScope returns the scope of the earliest registered binding of serviceType.
+func (c *container) boundScope(serviceType reflect.Type) (Scope, bool) {
+	c.mu.RLock()
+	var found *bindingDefinition
+	c.bindings.Range(func(key bindingKey, binding bindingDefinition) bool {
+		if key.typ == serviceType && (found == nil || binding.id < found.id) {
+			found = &binding
+		}
+		return true
+	})
+	c.mu.RUnlock()
+
+	if found == nil {
+		if c.parent != nil {
+			return c.parent.boundScope(serviceType)
+		}
+		return "", false
+	}
+	return found.scope, true
+}
@@ -0,0 +1,42 @@
+package digo
+
+import "reflect"
+
+// ResolveInterceptor is consulted before the container resolves a service.
+// Returning true makes the container use the returned instance as is, skipping
+// its own binding lookup and OnBoot.
+type ResolveInterceptor func(t reflect.Type, scope Scope) (Lifecycle, bool)
+
+// SetResolveInterceptor installs the interceptor consulted by every resolution,
+// typically to serve instances still held by another service locator.
+// Passing nil removes it. The returned function reinstates the interceptor
+// installed before.
+func SetResolveInterceptor(interceptor ResolveInterceptor) (restore func()) {
+	instance := GetContainer()
+	var installed *ResolveInterceptor
+	if interceptor != nil {
+		installed = &interceptor
+	}
+	previous := instance.interceptor.Swap(installed)
+	return func() { instance.interceptor.Store(previous) }
+}
+
+// intercept asks the installed interceptor for key. The boolean reports whether
+// the interceptor handled the resolution, in which case the result is returned.
+// Returns TypeMismatchError if the intercepted instance is not a T.
+func intercept[T Lifecycle](c *container, key bindingKey) (T, bool, error) {
+	var zero T
+	interceptor := c.interceptor.Load()
+	if interceptor == nil {
+		return zero, false, nil
+	}
+	service, ok := (*interceptor)(key.typ, key.scope)
+	if !ok {
+		return zero, false, nil
+	}
+	typed, ok := service.(T)
+	if !ok {
+		return zero, true, &TypeMismatchError{Expected: key.typ.String(), Got: reflect.TypeOf(service).String()}
+	}
+	return typed, true, nil
+}
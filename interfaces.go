@@ -10,6 +10,7 @@ type Lifecycle interface {
 
 	// OnShutdown is called when the service is being terminated.
 	// It should clean up any resources held by the service.
+	// The container calls it at most once per successful OnBoot.
 	OnShutdown(ctx *ContainerContext) error
 }
 
@@ -34,4 +35,6 @@ const (
 	ScopeRequest Scope = "request"
 	// ScopeSingleton shares a single instance across the application
 	ScopeSingleton Scope = "singleton"
+	// ScopeSession shares an instance within a session until EndSession
+	ScopeSession Scope = "session"
 )
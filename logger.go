@@ -0,0 +1,132 @@
+package digo
+
+import (
+	"reflect"
+	"runtime"
+	"sync/atomic"
+)
+
+// Logger receives diagnostic messages emitted by the container.
+// It is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// SetLogger installs the logger used for container diagnostics.
+// Passing nil disables diagnostic logging. The returned function reinstates
+// the logger installed before, so tests can undo their change with defer.
+func SetLogger(logger Logger) (restore func()) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	previous := instance.logger
+	instance.logger = logger
+	instance.mu.Unlock()
+	return func() {
+		instance.mu.Lock()
+		instance.logger = previous
+		instance.mu.Unlock()
+	}
+}
+
+// SetLeakDetection enables or disables reporting of leaked instances.
+// When enabled, Reset and Shutdown log every request or transient binding
+// that is still initialized but never had OnShutdown called.
+func SetLeakDetection(enabled bool) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.leakDetection = enabled
+	instance.mu.Unlock()
+}
+
+// SetFinalizerGuard enables or disables the finalizer guard. When enabled,
+// every pointer service booted afterwards gets a cleanup that logs a warning
+// if it is garbage collected while booted, i.e. without OnShutdown having run
+// since its last OnBoot. Cleanups are attached with runtime.AddCleanup, so they
+// coexist with finalizers set on the service and work for pointers into a
+// larger object. It is meant for development: cleanups cost time on every
+// boot. Like leak detection, it survives Reset.
+func SetFinalizerGuard(enabled bool) {
+	GetContainer().finalizerGuard.Store(enabled)
+}
+
+// guardKey identifies a guarded instance. A pointer to the first field of a
+// struct shares its address, so the type is part of the key.
+type guardKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// guardState tracks whether a guarded instance is booted.
+type guardState struct {
+	id   guardKey
+	key  string
+	live atomic.Bool
+}
+
+// guard marks service as booted and, on first sight, attaches its cleanup.
+// The cleanup must not reference service, or it would never be collected.
+func (c *container) guard(key bindingKey, service Lifecycle) {
+	if !c.finalizerGuard.Load() {
+		return
+	}
+	value := reflect.ValueOf(service)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return
+	}
+	state := &guardState{id: guardKey{addr: value.Pointer(), typ: value.Type()}, key: key.String()}
+	state.live.Store(true)
+	if existing, loaded := c.guarded.LoadOrStore(state.id, state); loaded {
+		existing.(*guardState).live.Store(true)
+		return
+	}
+	runtime.AddCleanup((*byte)(value.UnsafePointer()), func(state *guardState) {
+		c.guarded.Delete(state.id)
+		if state.live.Load() {
+			c.logf("digo: %s was garbage collected without OnShutdown", state.key)
+		}
+	}, state)
+}
+
+// unguard marks a guarded service as shut down.
+func (c *container) unguard(service Lifecycle) {
+	value := reflect.ValueOf(service)
+	if value.Kind() != reflect.Pointer {
+		return
+	}
+	if state, ok := c.guarded.Load(guardKey{addr: value.Pointer(), typ: value.Type()}); ok {
+		state.(*guardState).live.Store(false)
+	}
+}
+
+// logf writes a diagnostic message if a logger is installed.
+// Callers must not hold c.mu.
+func (c *container) logf(format string, v ...interface{}) {
+	c.mu.RLock()
+	logger := c.logger
+	c.mu.RUnlock()
+	if logger != nil {
+		logger.Printf(format, v...)
+	}
+}
+
+// collectLeaks returns a description of every non-singleton binding that is
+// still initialized. Callers must hold c.mu and report the result after unlocking.
+func (c *container) collectLeaks(bindings []bindingDefinition) []string {
+	if !c.leakDetection || c.logger == nil {
+		return nil
+	}
+	var leaks []string
+	for _, binding := range bindings {
+		if binding.scope != ScopeSingleton && binding.initialized {
+			leaks = append(leaks, string(binding.scope)+" "+reflect.TypeOf(binding.concrete).String()+" bound as "+binding.abstract.String())
+		}
+	}
+	return leaks
+}
+
+// reportLeaks logs the leaks gathered by collectLeaks.
+func (c *container) reportLeaks(leaks []string) {
+	for _, leak := range leaks {
+		c.logf("digo: leaked %s: initialized but OnShutdown was never called", leak)
+	}
+}
@@ -263,3 +263,40 @@ func (c *ComplexService) GetDB() Database {
 func (c *ComplexService) GetCache() Cache {
 	return c.Cache
 }
+
+// Generic services
+type User struct{ Name string }
+type Order struct{ ID int }
+
+type Repository[E any] interface {
+	digo.Lifecycle
+	Add(entity E)
+	All() []E
+}
+
+type MemoryRepository[E any] struct {
+	entities []E
+	booted   bool
+}
+
+func (r *MemoryRepository[E]) OnBoot(ctx *digo.ContainerContext) error {
+	r.booted = true
+	return nil
+}
+
+func (r *MemoryRepository[E]) OnShutdown(ctx *digo.ContainerContext) error {
+	r.booted = false
+	return nil
+}
+
+func (r *MemoryRepository[E]) Add(entity E) {
+	r.entities = append(r.entities, entity)
+}
+
+func (r *MemoryRepository[E]) All() []E {
+	return r.entities
+}
+
+func (r *MemoryRepository[E]) IsBooted() bool {
+	return r.booted
+}
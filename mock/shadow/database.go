@@ -0,0 +1,30 @@
+// Package mock provides types whose names collide with the parent mock package.
+// The package name is intentionally identical so that reflect.Type.String
+// renders both packages' types the same way.
+package mock
+
+import "github.com/centraunit/digo"
+
+// Database stringifies exactly like mock.Database but is a distinct type
+type Database interface {
+	digo.Lifecycle
+	Shadow() bool
+}
+
+type ShadowDB struct {
+	booted bool
+}
+
+func (s *ShadowDB) OnBoot(ctx *digo.ContainerContext) error {
+	s.booted = true
+	return nil
+}
+
+func (s *ShadowDB) OnShutdown(ctx *digo.ContainerContext) error {
+	s.booted = false
+	return nil
+}
+
+func (s *ShadowDB) Shadow() bool {
+	return s.booted
+}
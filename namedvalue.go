@@ -0,0 +1,101 @@
+package digo
+
+import (
+	"errors"
+	"reflect"
+)
+
+// namedValue is the type under which BindNamedValue keys its bindings, so
+// they never collide with bindings of a real type.
+type namedValue interface {
+	Lifecycle
+}
+
+var namedValueType = reflect.TypeOf((*namedValue)(nil)).Elem()
+
+// namedValueKey returns the key of the value bound under name with scope.
+func namedValueKey(scope Scope, name string) bindingKey {
+	key := makeBindingKey(scope, namedValueType)
+	key.name = name
+	return key
+}
+
+// BindNamedValue registers value under name alone, without a type, for wiring
+// driven by configuration where only string identifiers exist. Binding a name
+// again replaces its value, whatever the previous scope; in request scope only
+// the value of the same request is replaced. Replaced values that were booted
+// are shut down.
+// Returns InvalidScopeError unless scope is singleton, transient or request.
+// Returns NilServiceError if the value is nil.
+// Returns ShutdownError if a replaced value fails to shut down; the new value
+// is bound regardless.
+func BindNamedValue(name string, value Lifecycle, scope Scope, ctx *ContainerContext) error {
+	switch scope {
+	case ScopeSingleton, ScopeTransient, ScopeRequest:
+	default:
+		return &InvalidScopeError{Type: name, Scope: string(scope)}
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	binding, err := instance.newBinding(value, namedValueType, scope, ctx)
+	if err != nil {
+		instance.mu.Unlock()
+		return err
+	}
+	key := namedValueKey(scope, name)
+	if scope == ScopeRequest {
+		key.request = requestIDOf(binding.ctx)
+	}
+	var replaced []bindingDefinition
+	for _, previous := range instance.snapshot() {
+		if previous.key.typ != namedValueType || previous.key.name != name {
+			continue
+		}
+		if previous.scope == scope && previous.key != key {
+			// Values of other requests are kept, as with BindRequest
+			continue
+		}
+		instance.bindings.Delete(previous.key)
+		instance.initLocks.Delete(previous.key)
+		previous.stopReleasing()
+		base := previous.key
+		base.request = ""
+		if previous.key.request != "" && instance.latestRequest[base].request == previous.key.request {
+			delete(instance.latestRequest, base)
+		}
+		replaced = append(replaced, previous)
+	}
+	instance.register(namedValueKey(scope, name), binding)
+	instance.mu.Unlock()
+
+	var firstErr error
+	for _, previous := range replaced {
+		if !previous.initialized {
+			continue
+		}
+		if err := instance.shutdownBinding(previous.key, previous); err != nil && firstErr == nil {
+			firstErr = &ShutdownError{Type: reflect.TypeOf(previous.concrete).String(), Err: err}
+		}
+	}
+	return instance.wrapError(firstErr)
+}
+
+// ResolveNamedValue resolves the value bound under name with BindNamedValue,
+// with the semantics of the scope it was bound with.
+// Returns BindingNotFoundError if nothing is bound under name.
+func ResolveNamedValue(name string) (Lifecycle, error) {
+	instance := GetContainer()
+	for _, scope := range []Scope{ScopeSingleton, ScopeTransient} {
+		key := namedValueKey(scope, name)
+		if _, ok := instance.getBinding(key); ok || instance.parentOwner(key) != nil {
+			return wrapResult(resolveKey[Lifecycle](key))
+		}
+	}
+	service, err := resolveRequest[Lifecycle](namedValueKey(ScopeRequest, name))
+	var notFound *BindingNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, instance.wrapError(&BindingNotFoundError{Type: name, Request: notFound.Request})
+	}
+	return wrapResult(service, err)
+}
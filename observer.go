@@ -0,0 +1,87 @@
+package digo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Observer is notified of every container event as it happens, whether or not
+// the event log is enabled. It runs on the goroutine performing the operation,
+// often while the container holds its lock, and must not block.
+//
+// An observer must not call back into the container, directly or through
+// another goroutine it waits for: binding, resolving or inspecting from an
+// observer can deadlock. Hand events off, for instance over a buffered
+// channel, to act on them.
+type Observer func(event Event)
+
+// observerSet holds the registered observers. Writers replace the slice under
+// mu, so events are dispatched without taking a lock.
+type observerSet struct {
+	mu      sync.Mutex
+	nextID  uint64
+	entries atomic.Pointer[[]observerEntry]
+}
+
+type observerEntry struct {
+	id       uint64
+	observer Observer
+}
+
+// RegisterObserver adds observer to the container and returns a function that
+// removes it again. Observers outlive Reset; use ClearObservers to remove all.
+// See Observer for what an observer may do.
+func RegisterObserver(observer Observer) (unregister func()) {
+	set := &GetContainer().observers
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.nextID++
+	id := set.nextID
+	entries := append(set.list(), observerEntry{id: id, observer: observer})
+	set.entries.Store(&entries)
+	return func() { set.remove(id) }
+}
+
+// ClearObservers removes every registered observer.
+func ClearObservers() {
+	set := &GetContainer().observers
+	set.mu.Lock()
+	set.entries.Store(nil)
+	set.mu.Unlock()
+}
+
+// list returns a copy of the registered observers.
+func (s *observerSet) list() []observerEntry {
+	if entries := s.entries.Load(); entries != nil {
+		return append([]observerEntry(nil), (*entries)...)
+	}
+	return nil
+}
+
+func (s *observerSet) remove(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []observerEntry
+	for _, entry := range s.list() {
+		if entry.id != id {
+			entries = append(entries, entry)
+		}
+	}
+	s.entries.Store(&entries)
+}
+
+// notify passes event to every registered observer. Callers may hold c.mu,
+// which is why observers must not use the container.
+func (s *observerSet) notify(event Event) {
+	if entries := s.entries.Load(); entries != nil {
+		for _, entry := range *entries {
+			entry.observer(event)
+		}
+	}
+}
+
+// active reports whether any observer is registered.
+func (s *observerSet) active() bool {
+	entries := s.entries.Load()
+	return entries != nil && len(*entries) > 0
+}
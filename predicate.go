@@ -0,0 +1,271 @@
+package digo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MatchPredicate evaluates context conditions for a chained conditional binding.
+// Returning false means the binding does not apply and the next candidate is tried.
+// A nil Lifecycle with true selects the service registered alongside the predicate.
+// A nil MatchPredicate always matches.
+type MatchPredicate func(ctx *ContainerContext) (Lifecycle, bool, error)
+
+// matchCandidate pairs a chained predicate with the service it was bound with.
+type matchCandidate struct {
+	service   Lifecycle
+	predicate MatchPredicate
+}
+
+// BindTransientWhen appends a conditional candidate to a transient binding.
+// Candidates are evaluated in registration order and the first match wins.
+// The binding context of the first candidate is shared by the whole chain.
+// Returns NilServiceError if the service is nil.
+func BindTransientWhen[T Lifecycle](service T, ctx *ContainerContext, predicate MatchPredicate) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	return GetContainer().bindCandidate(service, serviceType, ScopeTransient, ctx, predicate)
+}
+
+// BindRequestWhen appends a conditional candidate to a request binding.
+// Candidates are evaluated in registration order and the first match wins.
+// The binding context of the first candidate is shared by the whole chain.
+// Returns NilServiceError if the service is nil.
+func BindRequestWhen[T Lifecycle](service T, ctx *ContainerContext, predicate MatchPredicate) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	return GetContainer().bindCandidate(service, serviceType, ScopeRequest, ctx, predicate)
+}
+
+func (c *container) bindCandidate(service Lifecycle, serviceType reflect.Type, scope Scope, ctx *ContainerContext, predicate MatchPredicate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	binding, err := c.newBinding(service, serviceType, scope, ctx)
+	if err != nil {
+		return err
+	}
+
+	key := makeBindingKey(scope, serviceType)
+	if scope == ScopeRequest {
+		key.request = requestIDOf(binding.ctx)
+	}
+	candidate := matchCandidate{service: service, predicate: predicate}
+	if existing, ok := c.bindings.Get(key); ok && len(existing.candidates) > 0 {
+		existing.candidates = append(existing.candidates[:len(existing.candidates):len(existing.candidates)], candidate)
+		c.bindings.Set(key, existing)
+		return nil
+	}
+
+	binding.candidates = []matchCandidate{candidate}
+	c.register(key, binding)
+	return nil
+}
+
+// SetPredicate attaches predicate to the existing transient or request binding
+// of T, replacing any predicate it had, so a configuration layer can make a
+// binding conditional after it was registered. Passing nil makes it unconditional.
+// Request bindings are looked up for the current request.
+// Returns BindingNotFoundError if T is not bound with scope.
+// Returns InvalidScopeError for ScopeSingleton, whose bindings are never conditional.
+func SetPredicate[T Lifecycle](scope Scope, predicate ContextPredicate) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if scope != ScopeTransient && scope != ScopeRequest {
+		return &InvalidScopeError{Type: serviceType.String(), Scope: string(scope)}
+	}
+	instance := GetContainer()
+	key := makeBindingKey(scope, serviceType)
+	if scope == ScopeRequest {
+		key = instance.currentRequestKey(key)
+	}
+
+	instance.mu.Lock()
+	binding, ok := instance.bindings.Get(key)
+	if !ok {
+		instance.mu.Unlock()
+		return instance.missingBinding(key)
+	}
+	if err := instance.checkSealed(serviceType); err != nil {
+		instance.mu.Unlock()
+		return err
+	}
+	binding.predicate = predicate
+	binding.typedPredicate = nil
+	instance.bindings.Set(key, binding)
+	instance.mu.Unlock()
+	return nil
+}
+
+// BindTransientWhenTyped registers a transient binding of T whose instance is
+// selected by predicate on resolution, as BindTransient with a ContextPredicate.
+// The compiler checks that predicate returns T, and resolution uses its result
+// as T directly rather than checking its type.
+// Returns NilServiceError if the service or predicate is nil.
+func BindTransientWhenTyped[T Lifecycle](service T, ctx *ContainerContext, predicate func(ctx *ContainerContext) (T, error)) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if predicate == nil {
+		return &NilServiceError{Type: serviceType.String()}
+	}
+	return GetContainer().bindTyped(service, serviceType, ScopeTransient, ctx, erasePredicate(predicate), predicate)
+}
+
+// BindRequestWhenTyped registers a request binding of T whose instance is
+// selected by predicate on resolution, as BindRequest with a ContextPredicate.
+// The compiler checks that predicate returns T, and resolution uses its result
+// as T directly rather than checking its type.
+// Returns NilServiceError if the service or predicate is nil.
+func BindRequestWhenTyped[T Lifecycle](service T, ctx *ContainerContext, predicate func(ctx *ContainerContext) (T, error)) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if predicate == nil {
+		return &NilServiceError{Type: serviceType.String()}
+	}
+	return GetContainer().bindTyped(service, serviceType, ScopeRequest, ctx, erasePredicate(predicate), predicate)
+}
+
+// bindTyped registers a binding whose predicate was given typed, keeping both
+// forms: the erased one for evaluation in general and the typed one for resolution.
+func (c *container) bindTyped(service Lifecycle, serviceType reflect.Type, scope Scope, ctx *ContainerContext, predicate ContextPredicate, typed any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	binding, err := c.newBinding(service, serviceType, scope, ctx)
+	if err != nil {
+		return err
+	}
+	binding.predicate = predicate
+	binding.typedPredicate = typed
+	c.register(makeBindingKey(scope, serviceType), binding)
+	return nil
+}
+
+// erasePredicate adapts a typed predicate to a ContextPredicate.
+func erasePredicate[T Lifecycle](predicate func(ctx *ContainerContext) (T, error)) ContextPredicate {
+	return func(ctx *ContainerContext) (Lifecycle, error) {
+		service, err := predicate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return service, nil
+	}
+}
+
+// chooseService runs the predicates of binding and returns the selected service
+// as T, reusing the choice made earlier in the request if perRequest is set.
+// A typed predicate already returns T, so only the result of an untyped one is
+// checked and can fail with PredicateError for an invalid type.
+func chooseService[T Lifecycle](c *container, key bindingKey, binding bindingDefinition, perRequest bool) (T, error) {
+	var zero T
+	typeName := key.typ.String()
+	predicate, typed := binding.typedPredicate.(func(ctx *ContainerContext) (T, error))
+	if typed && binding.predicate != nil {
+		var choice predicateChoice
+		ctx := c.activeRequest()
+		if perRequest && ctx != nil {
+			choice = predicateChoice{request: requestIDOf(ctx), key: key, id: binding.id}
+			if cached, ok := c.predicateCache.Load(choice); ok {
+				return cached.(T), nil
+			}
+		}
+		service, err := predicate(binding.ctx)
+		if err != nil {
+			return zero, &PredicateError{Type: typeName, Err: err}
+		}
+		if isNilService(service) {
+			return zero, &PredicateError{Type: typeName, Err: fmt.Errorf("predicate returned nil")}
+		}
+		if choice.request != "" {
+			c.predicateCache.Store(choice, service)
+		}
+		return service, nil
+	}
+
+	var result Lifecycle
+	var err error
+	if perRequest {
+		result, err = c.evaluateInRequest(key, binding)
+	} else {
+		result, err = binding.evaluate()
+	}
+	if err != nil {
+		return zero, err
+	}
+	service, ok := result.(T)
+	if !ok {
+		return zero, &PredicateError{Type: typeName, Err: fmt.Errorf("predicate returned invalid type")}
+	}
+	return service, nil
+}
+
+// hasCondition reports whether resolution must evaluate a predicate.
+func (b bindingDefinition) hasCondition() bool {
+	return b.predicate != nil || len(b.candidates) > 0
+}
+
+// predicateChoice identifies the instance a binding's predicates chose for one request.
+type predicateChoice struct {
+	request string
+	key     bindingKey
+	id      uint64
+}
+
+// evaluateInRequest evaluates the binding's predicates at most once per request:
+// inside a request begun with BeginRequest the chosen instance is reused until
+// EndRequest. Outside of a request the predicates run on every call.
+func (c *container) evaluateInRequest(key bindingKey, binding bindingDefinition) (Lifecycle, error) {
+	ctx := c.activeRequest()
+	if ctx == nil {
+		return binding.evaluate()
+	}
+	choice := predicateChoice{request: requestIDOf(ctx), key: key, id: binding.id}
+	if cached, ok := c.predicateCache.Load(choice); ok {
+		return cached.(Lifecycle), nil
+	}
+	result, err := binding.evaluate()
+	if err != nil {
+		return nil, err
+	}
+	c.predicateCache.Store(choice, result)
+	return result, nil
+}
+
+// clearPredicateChoices forgets the predicate results cached for requestID, or
+// for every request if requestID is empty.
+func (c *container) clearPredicateChoices(requestID string) {
+	c.predicateCache.Range(func(choice, _ interface{}) bool {
+		if requestID == "" || choice.(predicateChoice).request == requestID {
+			c.predicateCache.Delete(choice)
+		}
+		return true
+	})
+}
+
+// evaluate runs the binding's predicate or chained candidates and returns the selected service.
+func (b bindingDefinition) evaluate() (Lifecycle, error) {
+	typeName := b.abstract.String()
+	if b.predicate != nil {
+		result, err := b.predicate(b.ctx)
+		if err != nil {
+			return nil, &PredicateError{Type: typeName, Err: err}
+		}
+		if result == nil {
+			return nil, &PredicateError{Type: typeName, Err: fmt.Errorf("predicate returned invalid type")}
+		}
+		return result, nil
+	}
+
+	for _, candidate := range b.candidates {
+		if candidate.predicate == nil {
+			return candidate.service, nil
+		}
+		result, ok, err := candidate.predicate(b.ctx)
+		if err != nil {
+			return nil, &PredicateError{Type: typeName, Err: err}
+		}
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = candidate.service
+		}
+		return result, nil
+	}
+	return nil, &NoMatchingBindingError{Type: typeName}
+}
@@ -0,0 +1,186 @@
+package digo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// lifecycleType is the reflect type of Lifecycle.
+var lifecycleType = reflect.TypeOf((*Lifecycle)(nil)).Elem()
+
+// serviceProvider constructs a service on first use.
+type serviceProvider func(ctx *ContainerContext) (Lifecycle, error)
+
+// ProvideSingleton registers a singleton constructed by provider.
+// The provider runs exactly once, lazily on first resolution or during Boot,
+// and the instance it returns then participates in the normal lifecycle.
+// A provider error surfaces as InitializationError.
+func ProvideSingleton[T Lifecycle](provider func(ctx *ContainerContext) (T, error), ctx ...*ContainerContext) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if provider == nil {
+		return &NilServiceError{Type: serviceType.String()}
+	}
+	var bindingCtx *ContainerContext
+	if len(ctx) > 0 && ctx[0] != nil {
+		bindingCtx = ctx[0]
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newProvidedBinding(serviceType, ScopeSingleton, bindingCtx)
+	if err != nil {
+		return err
+	}
+	binding.provider = func(ctx *ContainerContext) (Lifecycle, error) {
+		return provider(ctx)
+	}
+	instance.register(makeBindingKey(ScopeSingleton, serviceType), binding)
+	return nil
+}
+
+// ProvideMulti registers a singleton constructed by provider under every type in
+// as. The provider runs once, and the instance it returns is resolvable as each
+// of the types; the bindings after the first are aliases of the first, so OnBoot
+// and OnShutdown run once. With no types, nothing is registered.
+// Returns NilServiceError if provider is nil.
+// Returns TypeMismatchError if a type does not implement Lifecycle; an instance
+// that does not implement every type surfaces as InitializationError.
+func ProvideMulti(provider func(ctx *ContainerContext) (Lifecycle, error), as ...reflect.Type) error {
+	if len(as) == 0 {
+		return nil
+	}
+	for _, serviceType := range as {
+		if serviceType == nil || !serviceType.Implements(lifecycleType) {
+			return &TypeMismatchError{Expected: lifecycleType.String(), Got: typeName(serviceType)}
+		}
+	}
+	if provider == nil {
+		return &NilServiceError{Type: as[0].String()}
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newProvidedBinding(as[0], ScopeSingleton, nil)
+	if err != nil {
+		return err
+	}
+	binding.provider = func(ctx *ContainerContext) (Lifecycle, error) {
+		service, err := provider(ctx)
+		if err != nil || isNilService(service) {
+			return service, err
+		}
+		for _, serviceType := range as {
+			if !reflect.TypeOf(service).AssignableTo(serviceType) {
+				return nil, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(service).String()}
+			}
+		}
+		return service, nil
+	}
+	key := makeBindingKey(ScopeSingleton, as[0])
+	instance.register(key, binding)
+
+	for _, serviceType := range as[1:] {
+		if serviceType == as[0] {
+			continue
+		}
+		alias, err := instance.newProvidedBinding(serviceType, ScopeSingleton, nil)
+		if err != nil {
+			return err
+		}
+		alias.aliasOf = key
+		instance.register(makeBindingKey(ScopeSingleton, serviceType), alias)
+	}
+	return nil
+}
+
+// BindOneOf registers a singleton chosen among candidates by selector.
+// The selector runs once with the binding context, during Boot or on first
+// resolution, and only the candidate it returns is booted; the others never
+// receive OnBoot or OnShutdown.
+// A selector error, or a selection that is not one of the candidates, surfaces
+// as InitializationError. Returns NilServiceError if selector or any candidate is nil.
+func BindOneOf[T Lifecycle](selector func(ctx *ContainerContext) (T, error), candidates ...T) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if selector == nil {
+		return &NilServiceError{Type: serviceType.String()}
+	}
+	for _, candidate := range candidates {
+		if isNilService(candidate) {
+			return &NilServiceError{Type: serviceType.String()}
+		}
+	}
+
+	return ProvideSingleton(func(ctx *ContainerContext) (T, error) {
+		selected, err := selector(ctx)
+		if err != nil {
+			return selected, err
+		}
+		for _, candidate := range candidates {
+			if sameService(candidate, selected) {
+				return selected, nil
+			}
+		}
+		return selected, &NoMatchingBindingError{Type: serviceType.String()}
+	})
+}
+
+// sameService reports whether a and b are the same service without panicking
+// on services that are not comparable. Comparable services are compared with
+// ==, funcs, maps and slices by identity, and other values, such as structs
+// holding slices, by deep equality.
+func sameService(a, b Lifecycle) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+	if va.Comparable() && vb.Comparable() {
+		return va.Equal(vb)
+	}
+	switch va.Kind() {
+	case reflect.Func, reflect.Map, reflect.Slice:
+		return va.Pointer() == vb.Pointer()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// newProvidedBinding builds a binding whose concrete is supplied later by a provider.
+// Callers must hold c.mu.
+func (c *container) newProvidedBinding(serviceType reflect.Type, scope Scope, ctx *ContainerContext) (bindingDefinition, error) {
+	if err := c.checkSealed(serviceType); err != nil {
+		return bindingDefinition{}, err
+	}
+	bindingCtx := c.withBase(ctx)
+	if err := checkScopeID(scope, bindingCtx); err != nil {
+		return bindingDefinition{}, err
+	}
+	return bindingDefinition{
+		scope:    scope,
+		abstract: serviceType,
+		id:       c.nextID.Add(1),
+		ctx:      bindingCtx,
+	}, nil
+}
+
+// materialize runs the binding's provider if its concrete has not been constructed yet.
+// The returned error is the provider's own; callers wrap it as InitializationError.
+func (b bindingDefinition) materialize() (bindingDefinition, error) {
+	if b.concrete != nil || b.provider == nil {
+		return b, nil
+	}
+	service, err := b.provider(b.ctx)
+	if err != nil {
+		return b, err
+	}
+	if isNilService(service) {
+		return b, fmt.Errorf("provider returned nil")
+	}
+	b.setConcrete(service)
+	return b, nil
+}
@@ -0,0 +1,115 @@
+package digo
+
+import "reflect"
+
+// Reboot shuts down the singleton bound for T and boots it again, for instance
+// to pick up reloaded configuration. ResolveSingleton calls for T made while
+// the reboot is in progress wait for it to complete instead of returning the
+// torn-down instance. A singleton that was never initialized is just booted.
+// Returns ShutdownError if OnShutdown fails, in which case the binding keeps
+// its initialized instance, and InitializationError if OnBoot fails, in which
+// case the next resolution boots it again.
+// Returns the errors of ResolveSingleton otherwise.
+func Reboot[T Lifecycle]() error {
+	instance := GetContainer()
+	return instance.wrapError(instance.reboot(makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem())))
+}
+
+func (c *container) reboot(key bindingKey) (err error) {
+	binding, ok := c.getBinding(key)
+	if !ok {
+		return c.missingBinding(key)
+	}
+	if binding.owner != nil {
+		return binding.owner.reboot(key)
+	}
+	if binding.isAlias() {
+		return c.reboot(binding.aliasOf)
+	}
+
+	c.beginReboot(key)
+	defer c.endReboot(key)
+
+	if err := c.startResolving(key); err != nil {
+		return err
+	}
+	defer c.finishResolving(key)
+
+	lock := c.initLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	binding, ok = c.getBinding(key)
+	if !ok {
+		return c.missingBinding(key)
+	}
+	if binding.initialized {
+		if err := c.shutdownBinding(key, binding); err != nil {
+			return &ShutdownError{Type: key.typ.String(), Err: err}
+		}
+		binding.initialized = false
+		c.storeBinding(key, binding)
+	}
+	if binding.concrete == nil {
+		if binding, err = binding.materialize(); err != nil {
+			return c.initializationError(key.typ, err)
+		}
+		c.storeBinding(key, binding)
+	}
+	if err := c.bootService(key, binding.concrete, c.bootContext(key, binding.ctx)); err != nil {
+		return c.initializationError(key.typ, err)
+	}
+	binding.initialized = true
+	c.storeBinding(key, binding)
+	return nil
+}
+
+// beginReboot marks key as rebooting, waiting for a reboot of key already in
+// progress to complete first.
+func (c *container) beginReboot(key bindingKey) {
+	c.rebootMu.Lock()
+	defer c.rebootMu.Unlock()
+	for c.rebooting[key] {
+		c.rebootDone.Wait()
+	}
+	if c.rebooting == nil {
+		c.rebooting = make(map[bindingKey]bool)
+	}
+	c.rebooting[key] = true
+	c.reboots.Add(1)
+}
+
+// endReboot clears the rebooting mark of key and wakes resolvers waiting on it.
+func (c *container) endReboot(key bindingKey) {
+	c.rebootMu.Lock()
+	defer c.rebootMu.Unlock()
+	delete(c.rebooting, key)
+	c.reboots.Add(-1)
+	c.rebootDone.Broadcast()
+}
+
+// awaitReboot blocks while key is rebooting and reports whether it waited, in
+// which case the caller must read the binding again. Resolutions nested in the
+// reboot itself do not wait, so they are reported as circular instead.
+func (c *container) awaitReboot(key bindingKey) bool {
+	if c.reboots.Load() == 0 {
+		return false
+	}
+	if state, ok := c.resolutionState.Load(c.getGoroutineID()); ok {
+		state := state.(*resolutionState)
+		state.mu.Lock()
+		nested := state.chain[key]
+		state.mu.Unlock()
+		if nested {
+			return false
+		}
+	}
+	c.rebootMu.Lock()
+	defer c.rebootMu.Unlock()
+	waited := false
+	for c.rebooting[key] {
+		c.rebootDone.Wait()
+		waited = true
+	}
+	return waited
+}
@@ -0,0 +1,24 @@
+package digo
+
+// Redacted replaces the value of redacted keys in diagnostic output.
+const Redacted = "[REDACTED]"
+
+// RedactKeys sets the context keys whose values are masked wherever the
+// container renders context values for diagnostics, such as ContainerContext.String.
+// Each call replaces the previous set; calling it without keys disables redaction.
+// Unlike bindings, the set survives Reset.
+func RedactKeys(keys ...interface{}) {
+	redacted := make(map[interface{}]bool, len(keys))
+	for _, key := range keys {
+		redacted[key] = true
+	}
+	GetContainer().redacted.Store(&redacted)
+}
+
+// redactValue returns val, or Redacted if key is a redacted key.
+func (c *container) redactValue(key, val interface{}) interface{} {
+	if redacted := c.redacted.Load(); redacted != nil && (*redacted)[key] {
+		return Redacted
+	}
+	return val
+}
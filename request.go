@@ -0,0 +1,342 @@
+package digo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// requestIDOf returns the request_id carried by ctx, or "" if there is none.
+func requestIDOf(ctx *ContainerContext) string {
+	requestID := ctx.Value("request_id")
+	if requestID == nil {
+		return ""
+	}
+	if str, ok := requestID.(string); ok {
+		return str
+	}
+	return fmt.Sprint(requestID)
+}
+
+// checkRequestID validates the request_id carried by ctx.
+// Returns MissingContextValueError if ctx has no request_id.
+// Returns InvalidContextValueError if the request_id is not a string.
+func checkRequestID(ctx *ContainerContext) error {
+	if ctx.Value("request_id") == nil {
+		return &MissingContextValueError{Key: "request_id"}
+	}
+	return checkStringValue(ctx, "request_id")
+}
+
+// checkStringValue validates that the value of key in ctx, if any, is a string.
+// Returns InvalidContextValueError if it is not.
+func checkStringValue(ctx *ContainerContext, key string) error {
+	value := ctx.Value(key)
+	if value == nil {
+		return nil
+	}
+	if _, ok := value.(string); !ok {
+		return &InvalidContextValueError{Key: key, Expected: "string", Got: reflect.TypeOf(value).String()}
+	}
+	return nil
+}
+
+// checkScopeID validates the request_id of a request binding context, or the
+// session_id of a session binding context, so a non-string id is rejected when
+// binding rather than stored under its printed form.
+// Returns InvalidContextValueError if the id is not a string.
+func checkScopeID(scope Scope, ctx *ContainerContext) error {
+	switch scope {
+	case ScopeRequest:
+		return checkStringValue(ctx, "request_id")
+	case ScopeSession:
+		return checkStringValue(ctx, "session_id")
+	}
+	return nil
+}
+
+// latestBinding is the request most recently bound for a type and the goroutine
+// that bound it.
+type latestBinding struct {
+	request   string
+	goroutine string
+}
+
+// requestKey scopes a request binding key to the request of its binding context
+// and remembers it as the latest request bound for the type.
+// Callers must hold c.mu.
+func (c *container) requestKey(key bindingKey, ctx *ContainerContext) bindingKey {
+	key.request = ""
+	base := key
+	key.request = requestIDOf(ctx)
+	c.latestRequest[base] = latestBinding{request: key.request, goroutine: c.getGoroutineID()}
+	c.endedRequests.forget(key.request)
+	return key
+}
+
+// BeginRequest associates the calling goroutine with the request identified by
+// the request_id in ctx, until EndRequest. While associated, ResolveRequest
+// returns the instances of that request: a request binding registered without a
+// request_id acts as a template, and each request resolving it receives its own
+// shallow copy booted with ctx, reused for the rest of the request.
+// Returns MissingContextValueError if ctx has no request_id.
+// Returns InvalidContextValueError if the request_id is not a string.
+func BeginRequest(ctx *ContainerContext) error {
+	if err := checkRequestID(ctx); err != nil {
+		return err
+	}
+	if requestIDOf(ctx) == "" {
+		return &MissingContextValueError{Key: "request_id"}
+	}
+	instance := GetContainer()
+	instance.endedRequests.forget(requestIDOf(ctx))
+	if _, loaded := instance.requests.Swap(instance.getGoroutineID(), ctx); !loaded {
+		instance.activeRequests.Add(1)
+	}
+	return nil
+}
+
+// WithRequestScope runs fn on the calling goroutine associated with the
+// request identified by the request_id in ctx, as BeginRequest does, and
+// restores the goroutine's previous association once fn returns. Handlers that
+// fan out use it in each spawned goroutine so ResolveRequest there sees the
+// instances of the handler's request:
+//
+//	go digo.WithRequestScope(ctx, func() { report, err = digo.ResolveRequest[Report]() })
+//
+// If ctx has no request_id, fn runs without a request association.
+func WithRequestScope(ctx *ContainerContext, fn func()) {
+	instance := GetContainer()
+	if requestIDOf(ctx) == "" {
+		instance.logf("digo: WithRequestScope: %v", &MissingContextValueError{Key: "request_id"})
+		fn()
+		return
+	}
+	id := instance.getGoroutineID()
+	previous, associated := instance.requests.Swap(id, ctx)
+	if !associated {
+		instance.activeRequests.Add(1)
+	}
+	defer func() {
+		// Leave the association alone if EndRequest or another scope replaced it
+		if associated {
+			instance.requests.CompareAndSwap(id, ctx, previous)
+		} else if instance.requests.CompareAndDelete(id, ctx) {
+			instance.activeRequests.Add(-1)
+		}
+	}()
+	fn()
+}
+
+// EndRequest shuts down and removes every request-scoped instance of requestID,
+// in reverse registration order, and ends its goroutine associations. Until the
+// request is begun or bound again, resolving its instances returns
+// RequestEndedError.
+// Returns the first ShutdownError encountered; the instances are removed regardless.
+func EndRequest(requestID string) error {
+	instance := GetContainer()
+	var goroutines []string
+	instance.requests.Range(func(id, ctx interface{}) bool {
+		if requestIDOf(ctx.(*ContainerContext)) == requestID {
+			if _, loaded := instance.requests.LoadAndDelete(id); loaded {
+				instance.activeRequests.Add(-1)
+				goroutines = append(goroutines, id.(string))
+			}
+		}
+		return true
+	})
+	instance.endedRequests.add(requestID, goroutines)
+	instance.clearPredicateChoices(requestID)
+
+	instance.mu.Lock()
+	var ended []bindingDefinition
+	for _, binding := range instance.snapshot() {
+		if binding.scope != ScopeRequest || binding.key.request != requestID {
+			continue
+		}
+		instance.bindings.Delete(binding.key)
+		instance.initLocks.Delete(binding.key)
+		binding.stopReleasing()
+		base := binding.key
+		base.request = ""
+		if instance.latestRequest[base].request == requestID {
+			delete(instance.latestRequest, base)
+		}
+		ended = append(ended, binding)
+	}
+	instance.mu.Unlock()
+
+	sort.Slice(ended, func(i, j int) bool { return ended[i].id > ended[j].id })
+	var firstErr error
+	for _, binding := range ended {
+		if !binding.initialized {
+			continue
+		}
+		if err := instance.shutdownBinding(binding.key, binding); err != nil && firstErr == nil {
+			firstErr = &ShutdownError{Type: reflect.TypeOf(binding.concrete).String(), Err: err}
+		}
+	}
+	return firstErr
+}
+
+// ResolveRequestOrNew returns the request-scoped T of the request_id in ctx,
+// first constructing it with factory and binding it with ctx if the request has
+// none. Concurrent calls for the same request construct at most one instance.
+// Returns MissingContextValueError if ctx has no request_id.
+// Returns InitializationError if factory or the service's OnBoot fails.
+func ResolveRequestOrNew[T Lifecycle](factory func(ctx *ContainerContext) (T, error), ctx *ContainerContext) (T, error) {
+	var zero T
+	instance := GetContainer()
+	requestID := requestIDOf(ctx)
+	if requestID == "" {
+		return zero, instance.wrapError(&MissingContextValueError{Key: "request_id"})
+	}
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	key := makeBindingKey(ScopeRequest, serviceType)
+	key.request = requestID
+
+	// Construct under the init lock so the factory runs once per request, then
+	// release it: resolving takes the same lock to boot the instance once
+	lock := instance.initLock(key)
+	lock.Lock()
+	instance.mu.RLock()
+	_, ok := instance.bindings.Get(key)
+	instance.mu.RUnlock()
+	if !ok {
+		service, err := factory(ctx)
+		if err != nil {
+			lock.Unlock()
+			return zero, instance.wrapError(&InitializationError{Type: serviceType.String(), Err: err})
+		}
+		if err := instance.bind(service, serviceType, ScopeRequest, ctx); err != nil {
+			lock.Unlock()
+			return zero, instance.wrapError(err)
+		}
+	}
+	lock.Unlock()
+	return wrapResult(resolveRequest[T](key))
+}
+
+// activeRequest returns the context the calling goroutine passed to BeginRequest, if any.
+func (c *container) activeRequest() *ContainerContext {
+	if c.activeRequests.Load() == 0 {
+		return nil
+	}
+	if ctx, ok := c.requests.Load(c.getGoroutineID()); ok {
+		return ctx.(*ContainerContext)
+	}
+	return nil
+}
+
+// clearRequests ends every goroutine association made by BeginRequest and
+// forgets the predicate results cached for them and the ended requests.
+func (c *container) clearRequests() {
+	c.clearPredicateChoices("")
+	c.endedRequests.reset()
+	c.requests.Range(func(id, _ interface{}) bool {
+		if _, loaded := c.requests.LoadAndDelete(id); loaded {
+			c.activeRequests.Add(-1)
+		}
+		return true
+	})
+}
+
+// currentRequestKey scopes a request binding key for resolution. It uses the
+// request begun on the calling goroutine, and otherwise the request the calling
+// goroutine most recently bound for the type. A goroutine never sees a request
+// another goroutine bound unless it begins that request, so a handler without
+// a binding of its own gets BindingNotFoundError rather than another request's
+// instance.
+func (c *container) currentRequestKey(key bindingKey) bindingKey {
+	if key.request != "" {
+		return key
+	}
+	if ctx := c.activeRequest(); ctx != nil {
+		key.request = requestIDOf(ctx)
+		return key
+	}
+	c.mu.RLock()
+	latest := c.latestRequest[key]
+	c.mu.RUnlock()
+	if latest.goroutine == c.getGoroutineID() {
+		key.request = latest.request
+	}
+	return key
+}
+
+// instantiateRequest registers the instance of a template request binding for
+// the request of key, as described in BeginRequest. The boolean is false if
+// there is no template. Returns NotCloneableError if the template's service
+// cannot be copied.
+func (c *container) instantiateRequest(key bindingKey, ctx *ContainerContext) (bindingDefinition, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if binding, ok := c.bindings.Get(key); ok {
+		return binding, true, nil
+	}
+	templateKey := key
+	templateKey.request = ""
+	template, ok := c.bindings.Get(templateKey)
+	if !ok {
+		return bindingDefinition{}, false, nil
+	}
+
+	binding := template
+	if template.concrete != nil && !template.hasCondition() {
+		if !isCloneable(template.concrete) {
+			return bindingDefinition{}, false, &NotCloneableError{Type: reflect.TypeOf(template.concrete).String()}
+		}
+		binding.setConcrete(cloneService(template.concrete))
+	}
+	binding.initialized = false
+	binding.ctx = template.ctx.MergeWith(ctx)
+	binding.ctx.Context = ctx.Context
+	binding.id = c.nextID.Add(1)
+	binding.key = key
+	c.register(key, binding)
+	return binding, true, nil
+}
+
+// releaseOnDone arranges for a request binding to be shut down and evicted once
+// its binding context is cancelled, e.g. when the client of an HTTP request
+// disconnects, and returns the func that unregisters the callback. Contexts
+// that can never be cancelled register nothing and return nil.
+func (c *container) releaseOnDone(key bindingKey, binding bindingDefinition) func() bool {
+	if binding.ctx.Done() == nil {
+		return nil
+	}
+	return context.AfterFunc(binding.ctx, func() {
+		if err := c.releaseRequest(key, binding.id); err != nil {
+			c.logf("digo: releasing cancelled request binding: %v", err)
+		}
+	})
+}
+
+// releaseRequest evicts the request binding identified by key and id, calling
+// OnShutdown if it was initialized. It does nothing if the binding was replaced.
+func (c *container) releaseRequest(key bindingKey, id uint64) error {
+	c.mu.Lock()
+	binding, ok := c.bindings.Get(key)
+	if !ok || binding.id != id {
+		c.mu.Unlock()
+		return nil
+	}
+	c.bindings.Delete(key)
+	c.initLocks.Delete(key)
+	base := key
+	base.request = ""
+	if c.latestRequest[base].request == key.request {
+		delete(c.latestRequest, base)
+	}
+	c.mu.Unlock()
+
+	if !binding.initialized {
+		return nil
+	}
+	if err := c.shutdownBinding(key, binding); err != nil {
+		return &ShutdownError{Type: reflect.TypeOf(binding.concrete).String(), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package digo
+
+import "reflect"
+
+// Resolver is a read-only view of the container for services that resolve
+// dependencies on demand, such as a dispatcher looking up handlers.
+// ResolveSingleton[Resolver] returns it without an explicit binding; binding
+// Resolver yourself replaces it.
+type Resolver interface {
+	Lifecycle
+	// Resolve resolves the binding of serviceType with the given scope.
+	Resolve(serviceType reflect.Type, scope Scope) (Lifecycle, error)
+	// IsBound reports whether serviceType is bound with the given scope.
+	IsBound(serviceType reflect.Type, scope Scope) bool
+}
+
+var resolverType = reflect.TypeOf((*Resolver)(nil)).Elem()
+
+// ResolveFrom resolves T with the given scope through r.
+// Returns TypeMismatchError if r returns a service that is not a T.
+func ResolveFrom[T Lifecycle](r Resolver, scope Scope) (T, error) {
+	var zero T
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	service, err := r.Resolve(serviceType, scope)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := service.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(service).String()}
+	}
+	return typed, nil
+}
+
+// ResolveSingletonPtr resolves the singleton bound for the type target points
+// to and stores it in *target, for callers without a compile-time type:
+//
+//	var db Database
+//	err := digo.ResolveSingletonPtr(&db)
+//
+// Returns InvalidTargetError if target is not a non-nil pointer.
+// Returns TypeMismatchError if the bound service is not assignable to *target.
+// Returns the errors of ResolveSingleton otherwise.
+func ResolveSingletonPtr(target any) error {
+	instance := GetContainer()
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return instance.wrapError(&InvalidTargetError{Type: typeName(reflect.TypeOf(target))})
+	}
+	serviceType := value.Type().Elem()
+	service, err := resolveSingleton[Lifecycle](makeBindingKey(ScopeSingleton, serviceType))
+	if err != nil {
+		return instance.wrapError(err)
+	}
+	if !reflect.TypeOf(service).AssignableTo(serviceType) {
+		return instance.wrapError(&TypeMismatchError{Expected: serviceType.String(), Got: reflect.TypeOf(service).String()})
+	}
+	value.Elem().Set(reflect.ValueOf(service))
+	return nil
+}
+
+// ResolveAllOf resolves each of types with the given scope and reports the
+// services and the failures separately, so one broken service does not hide the
+// state of the others. Each type is resolved on its own chain, so a cycle
+// fails only the types that are part of it.
+// A type that does not implement Lifecycle fails with TypeMismatchError.
+func ResolveAllOf(types []reflect.Type, scope Scope) (map[reflect.Type]Lifecycle, map[reflect.Type]error) {
+	instance := GetContainer()
+	services := make(map[reflect.Type]Lifecycle)
+	failures := make(map[reflect.Type]error)
+	for _, serviceType := range types {
+		if serviceType == nil || !serviceType.Implements(lifecycleType) {
+			failures[serviceType] = instance.wrapError(&TypeMismatchError{Expected: lifecycleType.String(), Got: typeName(serviceType)})
+			continue
+		}
+		service, err := resolveKey[Lifecycle](makeBindingKey(scope, serviceType))
+		if err != nil {
+			failures[serviceType] = instance.wrapError(err)
+			continue
+		}
+		services[serviceType] = service
+	}
+	return services, failures
+}
+
+// containerResolver is the Resolver registered implicitly for the container.
+type containerResolver struct {
+	c *container
+}
+
+func (r containerResolver) OnBoot(ctx *ContainerContext) error     { return nil }
+func (r containerResolver) OnShutdown(ctx *ContainerContext) error { return nil }
+
+func (r containerResolver) Resolve(serviceType reflect.Type, scope Scope) (Lifecycle, error) {
+	return wrapResult(resolveKey[Lifecycle](makeBindingKey(scope, serviceType)))
+}
+
+func (r containerResolver) IsBound(serviceType reflect.Type, scope Scope) bool {
+	return r.c.isBound(serviceType, scope)
+}
@@ -0,0 +1,82 @@
+package digo_test
+
+import (
+	"testing"
+
+	"github.com/centraunit/digo"
+	"github.com/stretchr/testify/suite"
+)
+
+type primaryMailer struct {
+	digo.AutoBinding `digo:"singleton,name=primary,tag=mail"`
+	booted           bool
+}
+
+func (m *primaryMailer) OnBoot(ctx *digo.ContainerContext) error     { m.booted = true; return nil }
+func (m *primaryMailer) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type auditLog struct {
+	digo.AutoBinding `digo:"transient"`
+}
+
+func (a *auditLog) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (a *auditLog) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type untaggedService struct{}
+
+func (u *untaggedService) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (u *untaggedService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type badScopeService struct {
+	digo.AutoBinding `digo:"global"`
+}
+
+func (b *badScopeService) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (b *badScopeService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type AutoBindTestSuite struct {
+	suite.Suite
+}
+
+func (s *AutoBindTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *AutoBindTestSuite) TestAutoBind() {
+	mailer := &primaryMailer{}
+	audit := &auditLog{}
+	s.NoError(digo.AutoBind(mailer, audit))
+
+	resolved, err := digo.ResolveNamed[*primaryMailer](digo.ScopeSingleton, "primary")
+	s.NoError(err)
+	s.Same(mailer, resolved)
+	s.True(mailer.booted)
+
+	tagged, err := digo.ResolveTagged[*primaryMailer]("mail")
+	s.NoError(err)
+	s.Len(tagged, 1)
+
+	log, err := digo.ResolveTransient[*auditLog]()
+	s.NoError(err)
+	s.Same(audit, log)
+}
+
+func (s *AutoBindTestSuite) TestInvalidTags() {
+	var tagErr *digo.InvalidTagError
+
+	err := digo.AutoBind(&auditLog{}, &untaggedService{})
+	s.ErrorAs(err, &tagErr)
+	s.Empty(tagErr.Tag)
+	s.False(digo.IsBound[*auditLog](digo.ScopeTransient), "Nothing should be bound when any service is invalid")
+
+	err = digo.AutoBind(&badScopeService{})
+	s.ErrorAs(err, &tagErr)
+	s.Equal("global", tagErr.Tag)
+
+	var nilErr *digo.NilServiceError
+	s.ErrorAs(digo.AutoBind(nil), &nilErr)
+}
+
+func TestAutoBindSuite(t *testing.T) {
+	suite.Run(t, new(AutoBindTestSuite))
+}
@@ -150,6 +150,27 @@ func BenchmarkConcurrentOperations(b *testing.B) {
 			wg.Wait()
 		}
 	})
+
+	b.Run("ConcurrentUnrelatedOperations", func(b *testing.B) {
+		// Binds of one type alongside resolutions of another
+		digo.Reset()
+		_ = digo.BindSingleton[mock.Database](&mock.MockDB{})
+		_, _ = digo.ResolveSingleton[mock.Database]()
+		b.SetParallelism(4)
+		b.ResetTimer()
+
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%2 == 0 {
+					_ = digo.BindSingleton[mock.Cache](&mock.MockCache{})
+				} else {
+					_, _ = digo.ResolveSingleton[mock.Database]()
+				}
+				i++
+			}
+		})
+	})
 }
 
 func BenchmarkContextOperations(b *testing.B) {
@@ -0,0 +1,266 @@
+package digo_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BuilderTestSuite struct {
+	suite.Suite
+}
+
+func (s *BuilderTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *BuilderTestSuite) TestFluentBinding() {
+	s.Run("ScopesMatchBindFunctions", func() {
+		ctx := digo.NewContainerContext(context.Background()).
+			WithValue("request_id", "builder")
+		singleton := &mock.MockDB{}
+		transient := &mock.MockDB{}
+		request := &mock.MockDB{}
+
+		s.NoError(digo.Bind[mock.Database](singleton).AsSingleton())
+		s.NoError(digo.Bind[mock.Database](transient).WithContext(ctx).AsTransient())
+		s.NoError(digo.Bind[mock.Database](request).WithContext(ctx).AsRequest())
+
+		instance, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+		s.Same(singleton, instance)
+
+		instance, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+		s.Same(transient, instance)
+
+		instance, err = digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+		s.Same(request, instance)
+		s.Equal("builder", request.RequestID)
+	})
+
+	s.Run("NamedBindings", func() {
+		digo.Reset()
+		primary := &mock.MockDB{}
+		replica := &mock.MockDB{}
+		s.NoError(digo.Bind[mock.Database](primary).Named("primary").AsSingleton())
+		s.NoError(digo.Bind[mock.Database](replica).Named("replica").AsSingleton())
+
+		instance, err := digo.ResolveNamed[mock.Database](digo.ScopeSingleton, "replica")
+		s.NoError(err)
+		s.Same(replica, instance)
+
+		instance, err = digo.ResolveNamed[mock.Database](digo.ScopeSingleton, "primary")
+		s.NoError(err)
+		s.Same(primary, instance)
+
+		// Named bindings do not satisfy unnamed resolution
+		_, err = digo.ResolveSingleton[mock.Database]()
+		var notFoundErr *digo.BindingNotFoundError
+		s.True(errors.As(err, &notFoundErr))
+	})
+
+	s.Run("TaggedBindingsInRegistrationOrder", func() {
+		digo.Reset()
+		first := &mock.MockDB{}
+		second := &mock.MockDB{}
+		s.NoError(digo.Bind[mock.Database](first).Named("first").Tagged("storage").AsSingleton())
+		s.NoError(digo.Bind[mock.Database](&mock.MockDB{}).Named("other").AsSingleton())
+		s.NoError(digo.Bind[mock.Database](second).Named("second").Tagged("storage", "fast").AsSingleton())
+
+		instances, err := digo.ResolveTagged[mock.Database]("storage")
+		s.NoError(err)
+		s.Len(instances, 2)
+		s.Same(first, instances[0])
+		s.Same(second, instances[1])
+	})
+
+	s.Run("PredicateWithTransient", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background()).WithValue("env", "prod")
+		prodDB := &mock.MockDB{}
+		err := digo.Bind[mock.Database](&mock.MockDB{}).
+			WithContext(ctx).
+			When(func(resolveCtx *digo.ContainerContext) (digo.Lifecycle, error) {
+				return prodDB, nil
+			}).
+			AsTransient()
+		s.NoError(err)
+
+		instance, err := digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+		s.Same(prodDB, instance)
+	})
+
+	s.Run("PredicateRejectedForSingleton", func() {
+		err := digo.Bind[mock.Database](&mock.MockDB{}).
+			When(func(*digo.ContainerContext) (digo.Lifecycle, error) { return nil, nil }).
+			AsSingleton()
+		var scopeErr *digo.InvalidScopeError
+		s.True(errors.As(err, &scopeErr))
+	})
+
+	s.Run("NilService", func() {
+		var db *mock.MockDB
+		err := digo.Bind[mock.Database](db).AsTransient()
+		var nilErr *digo.NilServiceError
+		s.True(errors.As(err, &nilErr))
+	})
+}
+
+func (s *BuilderTestSuite) TestBindAll() {
+	databaseType := reflect.TypeOf((*mock.Database)(nil)).Elem()
+	cacheType := reflect.TypeOf((*mock.Cache)(nil)).Elem()
+	primary := &mock.MockDB{}
+	replica := &mock.MockDB{}
+
+	err := digo.BindAll([]digo.BindSpec{
+		{Type: databaseType, Scope: digo.ScopeSingleton, Instance: primary},
+		{Type: databaseType, Scope: digo.ScopeSingleton, Instance: replica, Name: "replica"},
+	})
+	s.NoError(err)
+
+	db, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(primary, db)
+	db, err = digo.ResolveNamed[mock.Database](digo.ScopeSingleton, "replica")
+	s.NoError(err)
+	s.Same(replica, db)
+
+	err = digo.BindAll([]digo.BindSpec{
+		{Type: cacheType, Scope: digo.ScopeTransient, Instance: &mock.MockCache{}},
+		{Type: cacheType, Scope: digo.ScopeTransient, Instance: &mock.MockDB{}},
+	})
+	var specErr *digo.BindSpecError
+	s.ErrorAs(err, &specErr)
+	s.Equal(1, specErr.Index)
+	var mismatchErr *digo.TypeMismatchError
+	s.ErrorAs(err, &mismatchErr)
+
+	var scopeErr *digo.InvalidScopeError
+	s.ErrorAs(digo.BindByType(cacheType, "global", &mock.MockCache{}), &scopeErr)
+}
+
+func (s *BuilderTestSuite) TestShutdownTimeout() {
+	stuck := &stuckService{release: make(chan struct{})}
+	defer close(stuck.release)
+	s.NoError(digo.Bind[*stuckService](stuck).WithShutdownTimeout(10 * time.Millisecond).AsSingleton())
+	cache := &mock.MockDB{}
+	s.NoError(digo.Bind[mock.Database](cache).WithShutdownTimeout(time.Second).AsSingleton())
+	s.NoError(digo.Boot())
+
+	start := time.Now()
+	err := digo.Shutdown(true)
+	s.Less(time.Since(start), time.Second, "Shutdown should not wait for the stuck service")
+	var shutdownErr *digo.ShutdownError
+	s.Require().ErrorAs(err, &shutdownErr)
+	s.Equal("*digo_test.stuckService", shutdownErr.Type)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.True(stuck.sawDeadline.Load(), "OnShutdown should receive the deadline")
+	s.False(cache.IsConnected(), "Services within their budget should shut down")
+}
+
+// stuckService blocks in OnShutdown until released
+type stuckService struct {
+	release     chan struct{}
+	sawDeadline atomic.Bool
+}
+
+func (s *stuckService) OnBoot(ctx *digo.ContainerContext) error { return nil }
+
+func (s *stuckService) OnShutdown(ctx *digo.ContainerContext) error {
+	_, ok := ctx.Deadline()
+	s.sawDeadline.Store(ok)
+	<-s.release
+	return nil
+}
+
+// orderedService records the order in which services boot
+type orderedService struct {
+	name  string
+	order *[]string
+}
+
+func (o *orderedService) OnBoot(ctx *digo.ContainerContext) error {
+	*o.order = append(*o.order, o.name)
+	return nil
+}
+
+func (o *orderedService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *BuilderTestSuite) TestBootPriority() {
+	bind := func(order *[]string, name string, priority int) {
+		s.NoError(digo.Bind(&orderedService{name: name, order: order}).Named(name).WithBootPriority(priority).AsSingleton())
+	}
+	for _, boot := range []func() error{digo.Boot, digo.BootAll, func() error { return digo.BootAsyncN(1) }} {
+		digo.Reset()
+		var order []string
+		bind(&order, "metrics", 0)
+		bind(&order, "config", 10)
+		bind(&order, "cache", 0)
+		bind(&order, "database", 5)
+		bind(&order, "audit", 0)
+
+		s.NoError(boot())
+		s.Equal([]string{"config", "database", "audit", "cache", "metrics"}, order,
+			"Services should boot by priority, then by key")
+	}
+}
+
+func (s *BuilderTestSuite) TestNamedValues() {
+	ctx := digo.NewContainerContext(context.Background())
+	db := &mock.MockDB{}
+	s.NoError(digo.BindNamedValue("primary", db, digo.ScopeSingleton, ctx))
+	resolved, err := digo.ResolveNamedValue("primary")
+	s.NoError(err)
+	s.Same(db, resolved)
+	s.True(db.IsConnected())
+
+	// The name is independent of any typed binding
+	_, err = digo.ResolveSingleton[mock.Database]()
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound)
+
+	// Rebinding the name with another scope replaces the value
+	template := &mock.MockDB{}
+	s.NoError(digo.BindNamedValue("primary", template, digo.ScopeTransient, ctx))
+	replaced, err := digo.ResolveNamedValue("primary")
+	s.NoError(err)
+	s.Same(template, replaced)
+	s.False(db.IsConnected(), "The replaced value should be shut down")
+
+	requestCtx := digo.NewContainerContext(context.Background()).WithValue("request_id", "named")
+	s.NoError(digo.BindNamedValue("session", &mock.MockDB{}, digo.ScopeRequest, requestCtx))
+	requestValue, err := digo.ResolveNamedValue("session")
+	s.NoError(err)
+	s.Equal("named", requestValue.(*mock.MockDB).RequestID)
+
+	// Rebinding a request value's name replaces it too
+	s.NoError(digo.BindNamedValue("session", &mock.MockDB{}, digo.ScopeSingleton, ctx))
+	singletonValue, err := digo.ResolveNamedValue("session")
+	s.NoError(err)
+	s.Empty(singletonValue.(*mock.MockDB).RequestID)
+	s.False(requestValue.(*mock.MockDB).IsConnected(), "The replaced request value should be shut down")
+	s.Equal(2, digo.BindingCount(), "No request value should be left under the name")
+
+	_, err = digo.ResolveNamedValue("missing")
+	s.ErrorAs(err, &notFound)
+	s.Equal("missing", notFound.Type)
+
+	var scopeErr *digo.InvalidScopeError
+	s.ErrorAs(digo.BindNamedValue("cart", &mock.MockDB{}, digo.ScopeSession, ctx), &scopeErr)
+	var nilErr *digo.NilServiceError
+	s.ErrorAs(digo.BindNamedValue("nil", nil, digo.ScopeSingleton, ctx), &nilErr)
+}
+
+func TestBuilderSuite(t *testing.T) {
+	suite.Run(t, new(BuilderTestSuite))
+}
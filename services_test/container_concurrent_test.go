@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/centraunit/digo"
 	"github.com/centraunit/digo/mock"
@@ -131,6 +133,139 @@ func (s *ConcurrentTestSuite) TestConcurrentConditionalBindings() {
 
 }
 
+// bootGauge tracks how many OnBoot calls run at the same time
+type bootGauge struct {
+	active atomic.Int32
+	peak   atomic.Int32
+	booted atomic.Int32
+}
+
+type SlowService[T any] interface {
+	digo.Lifecycle
+	Kind() T
+}
+
+type slowService[T any] struct {
+	gauge *bootGauge
+}
+
+func (s *slowService[T]) OnBoot(ctx *digo.ContainerContext) error {
+	active := s.gauge.active.Add(1)
+	for {
+		peak := s.gauge.peak.Load()
+		if active <= peak || s.gauge.peak.CompareAndSwap(peak, active) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	s.gauge.active.Add(-1)
+	s.gauge.booted.Add(1)
+	return nil
+}
+
+func (s *slowService[T]) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *slowService[T]) Kind() T {
+	var zero T
+	return zero
+}
+
+func bindSlowServices(gauge *bootGauge) {
+	digo.BindSingleton[SlowService[int]](&slowService[int]{gauge: gauge})
+	digo.BindSingleton[SlowService[string]](&slowService[string]{gauge: gauge})
+	digo.BindSingleton[SlowService[bool]](&slowService[bool]{gauge: gauge})
+	digo.BindSingleton[SlowService[float64]](&slowService[float64]{gauge: gauge})
+}
+
+func (s *ConcurrentTestSuite) TestBootAsync() {
+	s.Run("BoundedParallelism", func() {
+		gauge := &bootGauge{}
+		bindSlowServices(gauge)
+
+		s.NoError(digo.BootAsyncN(2))
+		s.Equal(int32(4), gauge.booted.Load())
+		s.LessOrEqual(gauge.peak.Load(), int32(2))
+
+		// Booted singletons are not booted again
+		_, err := digo.ResolveSingleton[SlowService[int]]()
+		s.NoError(err)
+		s.Equal(int32(4), gauge.booted.Load())
+	})
+
+	s.Run("Unbounded", func() {
+		digo.Reset()
+		gauge := &bootGauge{}
+		bindSlowServices(gauge)
+
+		s.NoError(digo.BootAsync())
+		s.Equal(int32(4), gauge.booted.Load())
+	})
+
+	s.Run("ReportsFailure", func() {
+		digo.Reset()
+		s.NoError(digo.BindSingleton[mock.Database](&mock.FailingDB{ShouldFail: true}))
+
+		err := digo.BootAsyncN(1)
+		s.Error(err)
+		s.Contains(err.Error(), "simulated boot failure")
+	})
+}
+
+// rebootingService blocks in OnShutdown until released and counts its boots
+type rebootingService struct {
+	booted   atomic.Bool
+	boots    atomic.Int32
+	stopping chan struct{}
+	release  chan struct{}
+}
+
+func (r *rebootingService) OnBoot(ctx *digo.ContainerContext) error {
+	r.boots.Add(1)
+	r.booted.Store(true)
+	return nil
+}
+
+func (r *rebootingService) OnShutdown(ctx *digo.ContainerContext) error {
+	r.booted.Store(false)
+	close(r.stopping)
+	<-r.release
+	return nil
+}
+
+func (s *ConcurrentTestSuite) TestReboot() {
+	service := &rebootingService{stopping: make(chan struct{}), release: make(chan struct{})}
+	s.NoError(digo.BindSingleton[*rebootingService](service))
+	_, err := digo.ResolveSingleton[*rebootingService]()
+	s.NoError(err)
+
+	rebooted := make(chan error, 1)
+	go func() { rebooted <- digo.Reboot[*rebootingService]() }()
+	<-service.stopping
+
+	const resolvers = 8
+	var observed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < resolvers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resolved, err := digo.ResolveSingleton[*rebootingService]()
+			if err == nil && resolved.booted.Load() && resolved.boots.Load() == 2 {
+				observed.Add(1)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	s.Zero(observed.Load(), "Resolvers should wait while the singleton reboots")
+	close(service.release)
+
+	s.NoError(<-rebooted)
+	wg.Wait()
+	s.Equal(int32(resolvers), observed.Load(), "Every resolver should observe the rebooted instance")
+	s.Equal(int32(2), service.boots.Load())
+}
+
 func TestConcurrentSuite(t *testing.T) {
 	suite.Run(t, new(ConcurrentTestSuite))
 }
@@ -0,0 +1,141 @@
+package digo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConstructTestSuite struct {
+	suite.Suite
+}
+
+func (s *ConstructTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *ConstructTestSuite) TestConstructInjectsDependencies() {
+	db := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](db))
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindTransient[mock.Cache](&mock.MockCache{}, ctx))
+	s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+	report := &reportService{}
+	s.NoError(digo.BindSingleton[*reportService](report))
+
+	s.NoError(digo.Boot(), "Boot should inject dependencies without deadlocking")
+	s.Same(db, report.db, "Singleton bindings should be preferred")
+	s.NotNil(report.cache)
+	s.True(report.depsAtBoot, "Construct should run before OnBoot")
+
+	resolved, err := digo.ResolveSingleton[*reportService]()
+	s.NoError(err)
+	s.Same(report, resolved)
+}
+
+func (s *ConstructTestSuite) TestCycleDetectedBeforeBoot() {
+	a, b := &nodeA{}, &nodeB{}
+	s.NoError(digo.BindSingleton[*nodeA](a))
+	s.NoError(digo.BindSingleton[*nodeB](b))
+
+	var circularErr *digo.CircularDependencyError
+	s.ErrorAs(digo.CheckDependencies(), &circularErr)
+
+	_, err := digo.ResolveSingleton[*nodeA]()
+	s.ErrorAs(err, &circularErr)
+	s.Zero(a.boots, "OnBoot should not run for services in a cycle")
+	s.Zero(b.boots)
+}
+
+func (s *ConstructTestSuite) TestCheckDependencies() {
+	s.NoError(digo.BindSingleton[*reportService](&reportService{}))
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(digo.CheckDependencies(), &notFound)
+
+	s.NoError(digo.ProvideSingleton[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+		return &mock.MockDB{}, nil
+	}))
+	s.NoError(digo.BindSingleton[mock.Cache](&mock.MockCache{}))
+	s.NoError(digo.CheckDependencies(), "Provided bindings should satisfy dependencies")
+
+	s.NoError(digo.BindSingleton[*badConstruct](&badConstruct{}))
+	var constructErr *digo.InvalidConstructError
+	s.ErrorAs(digo.CheckDependencies(), &constructErr)
+	_, err := digo.ResolveSingleton[*badConstruct]()
+	s.ErrorAs(err, &constructErr)
+}
+
+func (s *ConstructTestSuite) TestDetectCycles() {
+	s.Empty(digo.DetectCycles())
+
+	digo.DependsOn[*nodeA, *nodeB]()
+	digo.DependsOn[*nodeB, mock.Database]()
+	digo.DependsOn[mock.Cache, mock.Database]()
+	s.Empty(digo.DetectCycles(), "An acyclic graph has no cycles")
+
+	digo.DependsOn[mock.Database, *nodeA]()
+	digo.DependsOn[*nodeB, *nodeA]()
+	digo.DependsOn[*reportService, *reportService]()
+	digo.DependsOn[*nodeB, *nodeA]()
+	s.Equal([][]string{
+		{"*digo_test.nodeA", "*digo_test.nodeB"},
+		{"*digo_test.nodeA", "*digo_test.nodeB", "mock.Database"},
+		{"*digo_test.reportService"},
+	}, digo.DetectCycles())
+
+	digo.Reset()
+	s.Empty(digo.DetectCycles(), "Reset should clear the declarations")
+}
+
+// reportService declares its dependencies through Construct
+type reportService struct {
+	db         mock.Database
+	cache      mock.Cache
+	depsAtBoot bool
+}
+
+func (r *reportService) Construct(db mock.Database, cache mock.Cache) error {
+	r.db = db
+	r.cache = cache
+	return nil
+}
+
+func (r *reportService) OnBoot(ctx *digo.ContainerContext) error {
+	r.depsAtBoot = r.db != nil && r.cache != nil
+	return nil
+}
+
+func (r *reportService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// nodeA and nodeB depend on each other through Construct
+type nodeA struct {
+	b     *nodeB
+	boots int
+}
+
+func (n *nodeA) Construct(b *nodeB)                          { n.b = b }
+func (n *nodeA) OnBoot(ctx *digo.ContainerContext) error     { n.boots++; return nil }
+func (n *nodeA) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type nodeB struct {
+	a     *nodeA
+	boots int
+}
+
+func (n *nodeB) Construct(a *nodeA)                          { n.a = a }
+func (n *nodeB) OnBoot(ctx *digo.ContainerContext) error     { n.boots++; return nil }
+func (n *nodeB) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// badConstruct has a Construct method the container cannot call
+type badConstruct struct{}
+
+func (b *badConstruct) Construct() (string, error)                  { return "", nil }
+func (b *badConstruct) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (b *badConstruct) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func TestConstructSuite(t *testing.T) {
+	suite.Run(t, new(ConstructTestSuite))
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/digotest"
 	"github.com/centraunit/digo/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -44,6 +46,39 @@ func (s *ContextTestSuite) TestContextInheritance() {
 		s.Equal("override-value", val)
 	})
 
+	s.Run("BaseMergePolicy", func() {
+		digo.Reset()
+		digo.SetBaseValue("shared", "base-value")
+		ctx := digo.NewContainerContext(context.Background()).WithValue("shared", "binding-value")
+
+		baseDB := &mock.MockDB{}
+		s.NoError(digo.BindSingleton[mock.Database](baseDB, ctx))
+		_, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+		val, err := baseDB.GetContextValue("shared")
+		s.NoError(err)
+		s.Equal("base-value", val, "The base context should win by default")
+
+		digo.SetBaseMergePolicy(digo.BindingWins)
+		bindingDB := &mock.MockDB{}
+		s.NoError(digo.BindTransient[mock.Database](bindingDB, ctx))
+		_, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+		val, err = bindingDB.GetContextValue("shared")
+		s.NoError(err)
+		s.Equal("binding-value", val, "The binding context should win with BindingWins")
+
+		digo.Reset()
+		digo.SetBaseValue("shared", "base-value")
+		resetDB := &mock.MockDB{}
+		s.NoError(digo.BindSingleton[mock.Database](resetDB, ctx))
+		_, err = digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+		val, err = resetDB.GetContextValue("shared")
+		s.NoError(err)
+		s.Equal("base-value", val, "Reset should restore BaseWins")
+	})
+
 	s.Run("ConditionalBindingWithContext", func() {
 		ctx := digo.NewContainerContext(context.Background()).
 			WithValue("env", "prod").
@@ -104,6 +139,192 @@ func (s *ContextTestSuite) TestMergeWith() {
 	s.Equal("value1", merged.Value("key1"))
 }
 
+// inheritingService resolves its database with the caller context inherited
+type inheritingService struct {
+	db mock.Database
+}
+
+func (i *inheritingService) OnBoot(ctx *digo.ContainerContext) error {
+	db, err := digo.ResolveTransientInheriting[mock.Database]()
+	i.db = db
+	return err
+}
+
+func (i *inheritingService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+func (i *inheritingService) IsInitialized() bool                         { return i.db != nil }
+
+func (s *ContextTestSuite) TestMergeWithTracked() {
+	base := digo.NewContainerContext(context.Background()).
+		WithValue("env", "dev").
+		WithValue("region", "eu").
+		WithValue("timeout", 5)
+	override := digo.NewContainerContext(context.Background()).
+		WithValue("timeout", 10).
+		WithValue("env", "prod").
+		WithValue("debug", true)
+
+	merged, overridden := base.MergeWithTracked(override)
+	s.Equal([]string{"env", "timeout"}, overridden)
+	s.Equal("prod", merged.Value("env"))
+	s.Equal("eu", merged.Value("region"))
+	s.Equal(10, merged.Value("timeout"))
+	s.Equal(true, merged.Value("debug"))
+
+	_, overridden = base.MergeWithTracked(nil)
+	s.Empty(overridden)
+}
+
+func (s *ContextTestSuite) TestResolveTransientInheriting() {
+	dbCtx := digo.NewContainerContext(context.Background()).
+		WithValue("pool", "small")
+	db := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](db, dbCtx))
+
+	callerCtx := digo.NewContainerContext(context.Background()).
+		WithValue("request_id", "req-inherit")
+	s.NoError(digo.BindSingleton[mock.Service](&inheritingService{}, callerCtx))
+
+	_, err := digo.ResolveSingleton[mock.Service]()
+	s.NoError(err)
+	s.Equal("req-inherit", db.RequestID)
+	val, err := db.GetContextValue("pool")
+	s.NoError(err)
+	s.Equal("small", val, "Binding values should still be visible")
+
+	// Without a resolving caller nothing is inherited
+	_, err = digo.ResolveTransientInheriting[mock.Database]()
+	s.NoError(err)
+	val, err = db.GetContextValue("request_id")
+	s.NoError(err)
+	s.Nil(val)
+}
+
+func (s *ContextTestSuite) TestSetBaseValue() {
+	defer digo.Reset()
+
+	ctx := digo.NewContainerContext(context.Background())
+	before := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](before, ctx))
+
+	digo.SetBaseValue("deployment_id", "deploy-42")
+
+	// Bindings registered earlier keep their snapshot
+	_, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	val, err := before.GetContextValue("deployment_id")
+	s.NoError(err)
+	s.Nil(val)
+
+	digotest.AssertContextValuePropagates(s.T(), digo.ScopeSingleton, "deployment_id", "deploy-42")
+}
+
+func (s *ContextTestSuite) TestWithLazyValue() {
+	calls := 0
+	ctx := digo.NewContainerContext(context.Background()).
+		WithLazyValue("tenant", func() interface{} {
+			calls++
+			return "acme"
+		})
+	s.Equal(0, calls, "Lazy value should not be computed before it is read")
+
+	derived := ctx.WithValue("other", 1)
+	s.Equal("acme", ctx.Value("tenant"))
+	s.Equal("acme", derived.Value("tenant"))
+	s.Equal("acme", derived.MergeWith(nil).Value("tenant"))
+	s.Equal(1, calls, "Lazy value should be computed at most once")
+
+	unread := digo.NewContainerContext(context.Background()).
+		WithLazyValue("tenant", func() interface{} {
+			s.Fail("Unread lazy value should never be computed")
+			return nil
+		})
+	s.Nil(unread.Value("missing"))
+}
+
+func (s *ContextTestSuite) TestString() {
+	parent := context.WithValue(context.Background(), "inherited", "hidden")
+	ctx := digo.NewContainerContext(parent).
+		WithValue("request_id", "req-1").
+		WithValue("env", "prod").
+		WithLazyValue("config", func() interface{} { return "loaded" })
+
+	s.Equal("ContainerContext{config: <lazy>, env: prod, request_id: req-1}", ctx.String())
+	s.Equal("ContainerContext{}", digo.NewContainerContext(context.Background()).String())
+}
+
+func (s *ContextTestSuite) TestWithTimeout() {
+	base := digo.NewContainerContext(context.Background()).WithValue("env", "prod")
+
+	bounded, cancel := base.WithTimeout(10 * time.Millisecond)
+	defer cancel()
+	_, ok := bounded.Deadline()
+	s.True(ok)
+	s.Equal("prod", bounded.Value("env"), "Stored values should be preserved")
+	<-bounded.Done()
+	s.ErrorIs(bounded.Err(), context.DeadlineExceeded)
+	s.NoError(base.Err(), "The parent context should not be cancelled")
+
+	cancellable, cancel := base.WithCancel()
+	s.Equal("prod", cancellable.Value("env"))
+	cancel()
+	s.ErrorIs(cancellable.Err(), context.Canceled)
+}
+
+func (s *ContextTestSuite) TestTypedValues() {
+	ctx := digo.NewContainerContext(context.Background()).
+		WithValue("request_id", "req-1").
+		WithValue("pool_size", 8).
+		WithValue("read_only", true).
+		WithLazyValue("region", func() interface{} { return "eu-west-1" })
+
+	requestID, ok := ctx.StringValue("request_id")
+	s.True(ok)
+	s.Equal("req-1", requestID)
+	region, ok := ctx.StringValue("region")
+	s.True(ok, "Lazy values should be evaluated")
+	s.Equal("eu-west-1", region)
+	size, ok := ctx.IntValue("pool_size")
+	s.True(ok)
+	s.Equal(8, size)
+	readOnly, ok := ctx.BoolValue("read_only")
+	s.True(ok)
+	s.True(readOnly)
+
+	_, ok = ctx.IntValue("request_id")
+	s.False(ok, "Values of another type should not convert")
+	_, ok = ctx.BoolValue("missing")
+	s.False(ok)
+
+	poolSize, ok := digo.ValueAs[int](ctx, "pool_size")
+	s.True(ok)
+	s.Equal(8, poolSize)
+}
+
+func (s *ContextTestSuite) TestRange() {
+	parent := context.WithValue(context.Background(), parentKey{}, "hidden")
+	ctx := digo.NewContainerContext(parent).
+		WithValue("env", "prod").
+		WithLazyValue("region", func() interface{} { return "eu-west-1" })
+
+	values := make(map[interface{}]interface{})
+	ctx.Range(func(key, value interface{}) bool {
+		values[key] = value
+		return true
+	})
+	s.Equal(map[interface{}]interface{}{"env": "prod", "region": "eu-west-1"}, values,
+		"Range should evaluate lazy values and skip parent context values")
+
+	visited := 0
+	ctx.Range(func(key, value interface{}) bool {
+		visited++
+		return false
+	})
+	s.Equal(1, visited, "Returning false should stop the iteration")
+}
+
+// parentKey keys a value stored on a parent context.Context
+type parentKey struct{}
+
 func TestContextSuite(t *testing.T) {
 	suite.Run(t, new(ContextTestSuite))
 }
@@ -0,0 +1,538 @@
+package digo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingLogger captures diagnostic messages for assertions
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) Messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+type DiagnosticsTestSuite struct {
+	suite.Suite
+	logger *recordingLogger
+}
+
+func (s *DiagnosticsTestSuite) SetupTest() {
+	digo.Reset()
+	s.logger = &recordingLogger{}
+	digo.SetLogger(s.logger)
+}
+
+func (s *DiagnosticsTestSuite) TearDownTest() {
+	digo.SetEventLogSize(0)
+	digo.SetHistoryLimit(0)
+	digo.ClearObservers()
+	digo.SetLeakDetection(false)
+	digo.SetLogger(nil)
+	digo.RedactKeys()
+	digo.Reset()
+}
+
+func (s *DiagnosticsTestSuite) TestLeakDetection() {
+	s.Run("ResetReportsInitializedTransient", func() {
+		digo.SetLeakDetection(true)
+		ctx := digo.NewContainerContext(context.Background())
+		err := digo.BindTransient[mock.Database](&mock.MockDB{}, ctx)
+		s.NoError(err)
+		_, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+
+		digo.Reset()
+		messages := s.logger.Messages()
+		s.Len(messages, 1)
+		s.Contains(messages[0], "mock.Database")
+	})
+
+	s.Run("ShutdownDoesNotReportCleanInstances", func() {
+		digo.SetLeakDetection(true)
+		s.logger.messages = nil
+		ctx := digo.NewContainerContext(context.Background())
+		err := digo.BindTransient[mock.Database](&mock.MockDB{}, ctx)
+		s.NoError(err)
+		_, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+
+		s.NoError(digo.Shutdown(false))
+		s.Empty(s.logger.Messages())
+	})
+
+	s.Run("DisabledByDefault", func() {
+		digo.SetLeakDetection(false)
+		s.logger.messages = nil
+		ctx := digo.NewContainerContext(context.Background())
+		err := digo.BindTransient[mock.Database](&mock.MockDB{}, ctx)
+		s.NoError(err)
+		_, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+
+		digo.Reset()
+		s.Empty(s.logger.Messages())
+	})
+}
+
+func (s *DiagnosticsTestSuite) TestFinalizerGuard() {
+	digo.SetFinalizerGuard(true)
+	defer digo.SetFinalizerGuard(false)
+	ctx := digo.NewContainerContext(context.Background())
+
+	// Shut down cleanly: no warning when collected
+	s.NoError(digo.BindTransient[mock.Cache](&mock.MockCache{}, ctx))
+	s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+	_, err := digo.ResolveTransient[mock.Cache]()
+	s.NoError(err)
+	s.NoError(digo.Shutdown(false))
+
+	// Dropped by Reset while booted: warned when collected
+	s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	digo.Reset()
+
+	s.Eventually(func() bool {
+		runtime.GC()
+		return len(s.logger.Messages()) > 0
+	}, 2*time.Second, 10*time.Millisecond, "A leaked instance should be reported once collected")
+	for _, message := range s.logger.Messages() {
+		s.Contains(message, "transient:mock.Database")
+		s.NotContains(message, "mock.Cache")
+	}
+}
+
+// finalizedService sets its own finalizer, as resource wrappers commonly do
+type finalizedService struct {
+	mock.MockDB
+}
+
+// configSection is bound by a pointer into an enclosing configuration
+type configSection struct {
+	mock.MockDB
+}
+
+func newFinalizedService() *finalizedService {
+	service := &finalizedService{}
+	runtime.SetFinalizer(service, func(*finalizedService) {})
+	return service
+}
+
+func (s *DiagnosticsTestSuite) TestFinalizerGuardWithFinalizers() {
+	digo.SetFinalizerGuard(true)
+	defer digo.SetFinalizerGuard(false)
+	ctx := digo.NewContainerContext(context.Background())
+
+	// A service that already has a finalizer can be guarded
+	s.NoError(digo.BindSingleton[*finalizedService](newFinalizedService(), ctx))
+	_, err := digo.ResolveSingleton[*finalizedService]()
+	s.NoError(err)
+
+	// A guarded service can still be given a finalizer
+	db := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](db, ctx))
+	_, err = digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	runtime.SetFinalizer(db, func(*mock.MockDB) {})
+
+	// A pointer into a larger object can be guarded
+	config := &struct {
+		Name    string
+		Section configSection
+	}{Name: "config"}
+	s.NoError(digo.BindTransient[*configSection](&config.Section, ctx))
+	_, err = digo.ResolveTransient[*configSection]()
+	s.NoError(err)
+	s.NoError(digo.Shutdown(true))
+}
+
+func (s *DiagnosticsTestSuite) TestSlowResolveWatcher() {
+	digo.SetSlowResolveThreshold(20 * time.Millisecond)
+	defer digo.SetSlowResolveThreshold(0)
+	gated := newGatedService()
+	s.NoError(digo.BindSingleton[*gatedService](gated))
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := digo.ResolveSingleton[*gatedService]()
+		resolved <- err
+	}()
+	<-gated.started
+
+	s.Eventually(func() bool {
+		return len(s.logger.Messages()) > 0
+	}, time.Second, 5*time.Millisecond, "A stuck resolution should be logged")
+	close(gated.release)
+	s.NoError(<-resolved)
+
+	messages := s.logger.Messages()
+	s.Len(messages, 1, "A slow key should be logged once")
+	s.Contains(messages[0], "singleton:*digo_test.gatedService")
+	s.Contains(messages[0], "chain:")
+}
+
+func eventKinds(events []digo.Event) []digo.EventKind {
+	kinds := make([]digo.EventKind, len(events))
+	for i, event := range events {
+		kinds[i] = event.Kind
+	}
+	return kinds
+}
+
+func (s *DiagnosticsTestSuite) TestEventLog() {
+	s.Run("DisabledByDefault", func() {
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		s.Empty(digo.RecentEvents())
+	})
+
+	s.Run("RecordsOperations", func() {
+		digo.SetEventLogSize(10)
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		_, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+		_, err = digo.ResolveTransient[mock.Cache]()
+		s.Error(err)
+		s.NoError(digo.Shutdown(true))
+
+		events := digo.RecentEvents()
+		s.Equal([]digo.EventKind{
+			digo.EventBind,
+			digo.EventBoot,
+			digo.EventResolve,
+			digo.EventError,
+			digo.EventShutdown,
+		}, eventKinds(events))
+		s.Equal("mock.Database", events[0].Type)
+		s.Equal(digo.ScopeSingleton, events[0].Scope)
+		s.False(events[0].Time.IsZero())
+		s.Equal("mock.Cache", events[3].Type)
+		s.Error(events[3].Err)
+	})
+
+	s.Run("RetainsOnlyMostRecent", func() {
+		digo.SetEventLogSize(2)
+		ctx := digo.NewContainerContext(context.Background())
+		s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+		s.NoError(digo.BindTransient[mock.Cache](&mock.MockCache{}, ctx))
+		_, err := digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+
+		events := digo.RecentEvents()
+		s.Equal([]digo.EventKind{digo.EventBoot, digo.EventResolve}, eventKinds(events))
+	})
+}
+
+func transitionKinds(transitions []digo.StateTransition) []digo.EventKind {
+	kinds := make([]digo.EventKind, len(transitions))
+	for i, transition := range transitions {
+		kinds[i] = transition.Kind
+	}
+	return kinds
+}
+
+func (s *DiagnosticsTestSuite) TestBindingHistory() {
+	s.Run("DisabledByDefault", func() {
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		s.Empty(digo.BindingHistory[mock.Database](digo.ScopeSingleton))
+	})
+
+	s.Run("RecordsTransitions", func() {
+		digo.SetHistoryLimit(10)
+		ctx := digo.NewContainerContext(context.Background())
+		s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+		_, err := digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+		_, err = digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+
+		history := digo.BindingHistory[mock.Database](digo.ScopeTransient)
+		s.Equal([]digo.EventKind{
+			digo.EventBind,
+			digo.EventBoot,
+			digo.EventShutdown,
+			digo.EventBoot,
+		}, transitionKinds(history), "Reusing a transient should shut it down before booting it again")
+		s.False(history[0].Time.IsZero())
+		s.Empty(digo.BindingHistory[mock.Database](digo.ScopeSingleton))
+	})
+
+	s.Run("SkipsFailedBoots", func() {
+		digo.SetHistoryLimit(10)
+		failing := &mock.FailingDB{ShouldFail: true}
+		s.NoError(digo.BindSingleton[mock.Database](failing))
+		_, err := digo.ResolveSingleton[mock.Database]()
+		s.Error(err)
+		failing.ShouldFail = false
+		_, err = digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+
+		s.Equal([]digo.EventKind{digo.EventBind, digo.EventBoot},
+			transitionKinds(digo.BindingHistory[mock.Database](digo.ScopeSingleton)))
+	})
+
+	s.Run("RetainsOnlyMostRecent", func() {
+		digo.SetHistoryLimit(2)
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		_, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+		s.NoError(digo.Shutdown(true))
+
+		s.Equal([]digo.EventKind{digo.EventBoot, digo.EventShutdown},
+			transitionKinds(digo.BindingHistory[mock.Database](digo.ScopeSingleton)))
+	})
+
+	s.Run("SharedAcrossRequests", func() {
+		digo.SetHistoryLimit(10)
+		for _, requestID := range []string{"req-1", "req-2"} {
+			ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", requestID)
+			s.NoError(digo.BindRequest[mock.Database](&mock.MockDB{}, ctx))
+			s.NoError(digo.EndRequest(requestID))
+		}
+
+		s.Equal([]digo.EventKind{digo.EventBind, digo.EventBind},
+			transitionKinds(digo.BindingHistory[mock.Database](digo.ScopeRequest)),
+			"Requests should not each get a history of their own")
+	})
+}
+
+func (s *DiagnosticsTestSuite) TestObservers() {
+	var first, second []digo.EventKind
+	unregister := digo.RegisterObserver(func(event digo.Event) { first = append(first, event.Kind) })
+	digo.RegisterObserver(func(event digo.Event) { second = append(second, event.Kind) })
+
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	_, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Equal([]digo.EventKind{digo.EventBind, digo.EventBoot, digo.EventResolve}, first, "Observers should see events with the event log disabled")
+	s.Equal(first, second)
+
+	unregister()
+	unregister()
+	_, err = digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Len(first, 3, "An unregistered observer should not be notified")
+	s.Len(second, 4)
+
+	digo.ClearObservers()
+	_, err = digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Len(second, 4, "ClearObservers should remove every observer")
+}
+
+func (s *DiagnosticsTestSuite) TestRestoreHooks() {
+	replacement := &recordingLogger{}
+	restoreLogger := digo.SetLogger(replacement)
+	restoreWrapper := digo.SetErrorWrapper(func(err error) error { return &tracedError{TraceID: "t-1", Err: err} })
+	restoreInterceptor := digo.SetResolveInterceptor(func(t reflect.Type, scope digo.Scope) (digo.Lifecycle, bool) {
+		return nil, false
+	})
+
+	_, err := digo.ResolveSingleton[mock.Database]()
+	var traced *tracedError
+	s.ErrorAs(err, &traced)
+
+	restoreInterceptor()
+	restoreWrapper()
+	restoreLogger()
+	_, err = digo.ResolveSingleton[mock.Database]()
+	s.False(errors.As(err, &traced), "The previous, absent, error wrapper should be reinstated")
+
+	digo.SetLeakDetection(true)
+	db := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](db, digo.NewContainerContext(context.Background())))
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	digo.Reset()
+	s.NotEmpty(s.logger.Messages(), "The suite's logger should be reinstated")
+	s.Empty(replacement.Messages())
+}
+
+func (s *DiagnosticsTestSuite) TestStats() {
+	digo.ResetStats()
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	for i := 0; i < 3; i++ {
+		_, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err)
+	}
+	_, err := digo.ResolveTransient[mock.Cache]()
+	s.Error(err)
+	s.NoError(digo.Shutdown(true))
+
+	stats := digo.Stats()
+	s.Equal(uint64(3), stats.Resolutions)
+	s.Equal(uint64(1), stats.Boots)
+	s.Equal(uint64(1), stats.Shutdowns)
+	s.Equal(uint64(1), stats.Errors)
+	s.Positive(stats.BootTime)
+
+	digo.Reset()
+	s.Equal(stats, digo.Stats(), "Reset should keep the counters")
+	digo.ResetStats()
+	s.Equal(digo.ResolutionStats{}, digo.Stats())
+}
+
+func (s *DiagnosticsTestSuite) TestCounts() {
+	s.Equal(0, digo.BindingCount())
+
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+	s.Equal(2, digo.BindingCount())
+
+	_, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Equal(0, digo.ResolutionStateCount(), "Resolution states should be released after resolving")
+
+	// Warm singletons are served without creating a resolution state
+	_, err = digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Equal(0, digo.ResolutionStateCount())
+
+	s.NoError(digo.Shutdown(false))
+	s.Equal(1, digo.BindingCount())
+}
+
+func (s *DiagnosticsTestSuite) TestListBindings() {
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.BindTransient[mock.Cache](&mock.MockCache{}, ctx, func(ctx *digo.ContainerContext) (digo.Lifecycle, error) {
+		return &mock.MockCache{}, nil
+	}))
+	s.NoError(digo.Bind[mock.Database](&mock.MockDB{}).Named("replica").Tagged("read").AsSingleton())
+	_, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+
+	infos := digo.ListBindings()
+	s.Require().Len(infos, 3)
+	s.Equal(digo.BindingInfo{Type: "mock.Database", Scope: digo.ScopeSingleton, Initialized: true}, infos[0])
+	s.Equal(digo.BindingInfo{Type: "mock.Cache", Scope: digo.ScopeTransient, Conditional: true}, infos[1])
+	s.Equal(digo.BindingInfo{Type: "mock.Database", Scope: digo.ScopeSingleton, Name: "replica", Tags: []string{"read"}}, infos[2])
+
+	s.True(digo.HasPredicate[mock.Cache](digo.ScopeTransient))
+	s.False(digo.HasPredicate[mock.Database](digo.ScopeSingleton), "Unconditional bindings have no predicate")
+	s.False(digo.HasPredicate[mock.Cache](digo.ScopeSingleton), "Unbound types have no predicate")
+}
+
+func (s *DiagnosticsTestSuite) TestPendingSingletons() {
+	s.Empty(digo.PendingSingletons())
+
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.Bind[mock.Database](&mock.MockDB{}).Named("replica").AsSingleton())
+	s.NoError(digo.BindTransient[mock.Cache](&mock.MockCache{}, ctx))
+	s.Equal([]string{"mock.Database", "mock.Database#replica"}, digo.PendingSingletons(), "Transient bindings are never pending")
+
+	_, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Equal([]string{"mock.Database#replica"}, digo.PendingSingletons())
+}
+
+func (s *DiagnosticsTestSuite) TestResolveAllOf() {
+	ctx := digo.NewContainerContext(context.Background())
+	db := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.CircularService1](&mock.CircularImpl1{}, ctx))
+	s.NoError(digo.BindTransient[mock.CircularService2](&mock.CircularImpl2{}, ctx))
+	s.NoError(digo.BindTransient[mock.Database](db, ctx))
+
+	circularType := reflect.TypeOf((*mock.CircularService1)(nil)).Elem()
+	databaseType := reflect.TypeOf((*mock.Database)(nil)).Elem()
+	cacheType := reflect.TypeOf((*mock.Cache)(nil)).Elem()
+	stringType := reflect.TypeOf("")
+	services, failures := digo.ResolveAllOf([]reflect.Type{circularType, databaseType, cacheType, stringType}, digo.ScopeTransient)
+
+	s.Len(services, 1)
+	s.Same(db, services[databaseType], "A cycle should not fail the types resolved after it")
+	s.True(db.IsConnected())
+
+	s.Len(failures, 3)
+	var circularErr *digo.CircularDependencyError
+	s.ErrorAs(failures[circularType], &circularErr)
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(failures[cacheType], &notFound)
+	var mismatch *digo.TypeMismatchError
+	s.ErrorAs(failures[stringType], &mismatch)
+}
+
+func (s *DiagnosticsTestSuite) TestBoundScope() {
+	_, ok := digo.BoundScope[mock.Database]()
+	s.False(ok)
+
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
+	s.NoError(digo.Bind[mock.Database](&mock.MockDB{}).Named("replica").AsTransient())
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.BindRequest[mock.Cache](&mock.MockCache{}, ctx))
+
+	scope, ok := digo.BoundScope[mock.Database]()
+	s.True(ok)
+	s.Equal(digo.ScopeTransient, scope, "The earliest binding should win, whatever its name")
+	scope, ok = digo.BoundScope[mock.Cache]()
+	s.True(ok)
+	s.Equal(digo.ScopeRequest, scope)
+	_, ok = digo.BoundScope[mock.DeepService3]()
+	s.False(ok)
+}
+
+func (s *DiagnosticsTestSuite) TestRedactKeys() {
+	ctx := digo.NewContainerContext(context.Background()).
+		WithValue("request_id", "req-1").
+		WithValue("auth_token", "s3cret")
+	s.Equal("ContainerContext{auth_token: s3cret, request_id: req-1}", ctx.String())
+
+	digo.RedactKeys("auth_token")
+	rendered := fmt.Sprint(ctx)
+	s.NotContains(rendered, "s3cret")
+	s.Equal("ContainerContext{auth_token: "+digo.Redacted+", request_id: req-1}", rendered)
+	s.Equal("s3cret", ctx.Value("auth_token"), "Redaction only affects diagnostic output")
+
+	digo.RedactKeys()
+	s.Contains(ctx.String(), "s3cret")
+}
+
+// panickingService panics during OnBoot
+type panickingService struct{}
+
+func (p *panickingService) OnBoot(ctx *digo.ContainerContext) error     { panic("boot failed") }
+func (p *panickingService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *DiagnosticsTestSuite) TestResolutionStateAfterPanic() {
+	s.NoError(digo.BindSingleton[*panickingService](&panickingService{}))
+
+	for i := 0; i < 2; i++ {
+		s.PanicsWithValue("boot failed", func() {
+			_, _ = digo.ResolveSingleton[*panickingService]()
+		}, "A retry must panic again rather than report a circular dependency")
+		s.Equal(0, digo.ResolutionStateCount(), "A panicking resolution should release its state")
+	}
+
+	digo.ClearResolutionState()
+	s.Equal(0, digo.ResolutionStateCount())
+}
+
+func TestDiagnosticsSuite(t *testing.T) {
+	suite.Run(t, new(DiagnosticsTestSuite))
+}
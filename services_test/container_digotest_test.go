@@ -0,0 +1,90 @@
+package digo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/digotest"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// fatalRecorder captures Fatalf calls instead of stopping the test
+type fatalRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+type DigotestTestSuite struct {
+	suite.Suite
+}
+
+func (s *DigotestTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *DigotestTestSuite) TestRequireHelpers() {
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.BindRequest[mock.Database](&mock.MockDB{}, ctx))
+
+	digotest.RequireBound[mock.Database](s.T(), digo.ScopeSingleton)
+	digotest.RequireBound[mock.Database](s.T(), digo.ScopeRequest)
+
+	db := digotest.RequireResolves[mock.Database](s.T(), digo.ScopeSingleton)
+	s.True(db.(*mock.MockDB).IsConnected())
+	db = digotest.RequireResolves[mock.Database](s.T(), digo.ScopeRequest)
+	s.True(db.(*mock.MockDB).IsConnected())
+
+	s.False(digo.IsBound[mock.Database](digo.ScopeTransient))
+	s.False(digo.IsBound[mock.Cache](digo.ScopeSingleton))
+}
+
+func (s *DigotestTestSuite) TestRequireHelpersFail() {
+	recorder := &fatalRecorder{TB: s.T()}
+
+	digotest.RequireBound[mock.Database](recorder, digo.ScopeTransient)
+	db := digotest.RequireResolves[mock.Database](recorder, digo.ScopeSingleton)
+
+	s.Nil(db)
+	s.Len(recorder.failures, 2)
+	s.Contains(recorder.failures[0], "mock.Database is not bound with transient scope")
+	s.Contains(recorder.failures[1], "no binding found")
+}
+
+func (s *DigotestTestSuite) TestAssertContextValuePropagates() {
+	defer digo.Reset()
+	digo.SetBaseValue("deployment_id", "deploy-42")
+	digotest.AssertContextValuePropagates(s.T(), digo.ScopeSingleton, "deployment_id", "deploy-42")
+	digotest.AssertContextValuePropagates(s.T(), digo.ScopeTransient, "deployment_id", "deploy-42")
+
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-7")
+	s.NoError(digo.BeginRequest(ctx))
+	defer digo.EndRequest("req-7")
+	digotest.AssertContextValuePropagates(s.T(), digo.ScopeRequest, "request_id", "req-7")
+}
+
+func (s *DigotestTestSuite) TestAssertContextValuePropagatesFail() {
+	recorder := &fatalRecorder{TB: s.T()}
+
+	digotest.AssertContextValuePropagates(recorder, digo.ScopeSingleton, "deployment_id", "deploy-42")
+	digotest.AssertContextValuePropagates(recorder, digo.ScopeRequest, "request_id", "req-7")
+	digotest.AssertContextValuePropagates(recorder, digo.ScopeSession, "session_id", "sess-1")
+
+	s.Len(recorder.failures, 3)
+	s.Contains(recorder.failures[0], `context value "deployment_id" in OnBoot with singleton scope is <nil>, expected deploy-42`)
+	s.Contains(recorder.failures[1], "resolving context probe with request scope")
+	s.Contains(recorder.failures[2], "not supported with session scope")
+}
+
+func TestDigotestSuite(t *testing.T) {
+	suite.Run(t, new(DigotestTestSuite))
+}
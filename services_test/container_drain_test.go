@@ -0,0 +1,131 @@
+package digo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// gatedService blocks in OnBoot until released, then resolves the database
+type gatedService struct {
+	started      chan struct{}
+	release      chan struct{}
+	db           mock.Database
+	dbAtShutdown bool
+}
+
+func newGatedService() *gatedService {
+	return &gatedService{started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (g *gatedService) OnBoot(ctx *digo.ContainerContext) error {
+	close(g.started)
+	<-g.release
+	var err error
+	g.db, err = digo.ResolveSingleton[mock.Database]()
+	return err
+}
+
+func (g *gatedService) OnShutdown(ctx *digo.ContainerContext) error {
+	g.dbAtShutdown = g.db.(*mock.MockDB).IsConnected()
+	return nil
+}
+
+type DrainTestSuite struct {
+	suite.Suite
+}
+
+func (s *DrainTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *DrainTestSuite) TearDownTest() {
+	digo.Reset()
+}
+
+func (s *DrainTestSuite) TestDrainWaitsForInFlight() {
+	db := &mock.MockDB{}
+	gated := newGatedService()
+	s.NoError(digo.BindSingleton[mock.Database](db))
+	s.NoError(digo.BindSingleton[*gatedService](gated))
+
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := digo.ResolveSingleton[*gatedService]()
+		resolved <- err
+	}()
+	<-gated.started
+
+	drained := make(chan error, 1)
+	go func() { drained <- digo.Drain(context.Background()) }()
+
+	s.Eventually(func() bool {
+		_, err := digo.ResolveSingleton[mock.Database]()
+		var closingErr *digo.ContainerClosingError
+		return errors.As(err, &closingErr)
+	}, time.Second, time.Millisecond, "New resolutions should be rejected while draining")
+	s.Empty(drained, "Drain should wait for the in-flight resolution")
+
+	close(gated.release)
+	s.NoError(<-resolved, "Nested resolutions of in-flight work should be admitted")
+	s.NoError(<-drained)
+
+	s.False(db.IsConnected())
+	s.True(gated.dbAtShutdown, "Services should shut down in reverse registration order")
+	s.Equal(0, digo.BindingCount())
+}
+
+func (s *DrainTestSuite) TestDrainDeadline() {
+	gated := newGatedService()
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	s.NoError(digo.BindSingleton[*gatedService](gated))
+
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := digo.ResolveSingleton[*gatedService]()
+		resolved <- err
+	}()
+	<-gated.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(digo.Drain(ctx), context.DeadlineExceeded)
+
+	close(gated.release)
+	s.NoError(<-resolved)
+	s.Equal(2, digo.BindingCount(), "A failed drain should not shut anything down")
+}
+
+// drainingService calls Drain from its own OnBoot
+type drainingService struct {
+	drainErr error
+}
+
+func (d *drainingService) OnBoot(ctx *digo.ContainerContext) error {
+	d.drainErr = digo.Drain(context.Background())
+	return nil
+}
+
+func (d *drainingService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *DrainTestSuite) TestDrainInResolution() {
+	service := &drainingService{}
+	s.NoError(digo.BindSingleton[*drainingService](service))
+
+	_, err := digo.ResolveSingleton[*drainingService]()
+	s.NoError(err)
+	var inResolution *digo.DrainInResolutionError
+	s.ErrorAs(service.drainErr, &inResolution, "Drain should not wait for the resolution calling it")
+
+	_, err = digo.ResolveSingleton[*drainingService]()
+	s.NoError(err, "A rejected drain should leave the container open")
+}
+
+func TestDrainSuite(t *testing.T) {
+	suite.Run(t, new(DrainTestSuite))
+}
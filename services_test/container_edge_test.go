@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 
 	"github.com/centraunit/digo"
 	"github.com/centraunit/digo/mock"
+	shadow "github.com/centraunit/digo/mock/shadow"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -215,6 +217,61 @@ func (s *EdgeCaseTestSuite) TestResolveSingletonEdgeCases() {
 	s.Contains(err.Error(), "simulated boot failure")
 }
 
+func (s *EdgeCaseTestSuite) TestIdenticallyNamedTypes() {
+	s.Equal(
+		reflect.TypeOf((*mock.Database)(nil)).Elem().String(),
+		reflect.TypeOf((*shadow.Database)(nil)).Elem().String(),
+		"Precondition: both types must stringify identically",
+	)
+
+	db := &mock.MockDB{}
+	shadowDB := &shadow.ShadowDB{}
+	s.NoError(digo.BindSingleton[mock.Database](db))
+	s.NoError(digo.BindSingleton[shadow.Database](shadowDB))
+
+	instance, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(db, instance)
+
+	shadowInstance, err := digo.ResolveSingleton[shadow.Database]()
+	s.NoError(err)
+	s.Same(shadowDB, shadowInstance)
+	s.True(shadowInstance.Shadow())
+}
+
+func (s *EdgeCaseTestSuite) TestGenericInstantiations() {
+	users := &mock.MemoryRepository[mock.User]{}
+	orders := &mock.MemoryRepository[mock.Order]{}
+	s.NoError(digo.BindSingleton[mock.Repository[mock.User]](users))
+	s.NoError(digo.BindSingleton[mock.Repository[mock.Order]](orders))
+
+	userRepo, err := digo.ResolveSingleton[mock.Repository[mock.User]]()
+	s.NoError(err)
+	s.Same(users, userRepo)
+	orderRepo, err := digo.ResolveSingleton[mock.Repository[mock.Order]]()
+	s.NoError(err)
+	s.Same(orders, orderRepo)
+
+	userRepo.Add(mock.User{Name: "ada"})
+	s.Len(userRepo.All(), 1)
+	s.Empty(orderRepo.All(), "Instantiations should not share instances")
+	s.True(users.IsBooted())
+	s.True(orders.IsBooted())
+
+	ctx := digo.NewContainerContext(context.Background())
+	transientUsers := &mock.MemoryRepository[mock.User]{}
+	s.NoError(digo.BindTransient[mock.Repository[mock.User]](transientUsers, ctx))
+	transient, err := digo.ResolveTransient[mock.Repository[mock.User]]()
+	s.NoError(err)
+	s.Same(transientUsers, transient)
+	s.NotSame(userRepo, transient, "Scopes of the same instantiation should stay separate")
+
+	_, err = digo.ResolveTransient[mock.Repository[mock.Order]]()
+	var mismatchErr *digo.ScopeMismatchError
+	s.ErrorAs(err, &mismatchErr, "Only the User instantiation is bound as transient")
+	s.Contains(mismatchErr.Type, "Order")
+}
+
 func TestEdgeCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(EdgeCaseTestSuite))
 }
@@ -3,6 +3,7 @@ package digo_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/centraunit/digo"
@@ -37,6 +38,26 @@ func (s *ErrorTestSuite) TestErrorCases() {
 		s.Contains(err.Error(), "no binding found")
 	})
 
+	s.Run("ScopeMismatch", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background())
+		s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+
+		_, err := digo.ResolveSingleton[mock.Database]()
+		var mismatchErr *digo.ScopeMismatchError
+		s.ErrorAs(err, &mismatchErr)
+		s.Equal(digo.ScopeTransient, mismatchErr.BoundScope)
+		s.Equal(digo.ScopeSingleton, mismatchErr.RequestedScope)
+		s.Equal("mock.Database", mismatchErr.Type)
+
+		_, _, err = digo.PeekSingleton[mock.Database]()
+		s.ErrorAs(err, &mismatchErr)
+
+		_, err = digo.ResolveSingleton[mock.Cache]()
+		var notFound *digo.BindingNotFoundError
+		s.ErrorAs(err, &notFound, "Types bound in no scope are still reported as not found")
+	})
+
 	s.Run("NilBinding", func() {
 		var db *mock.MockDB
 		err := digo.BindSingleton[mock.Database](db)
@@ -44,6 +65,13 @@ func (s *ErrorTestSuite) TestErrorCases() {
 		s.True(errors.As(err, &nilErr))
 	})
 
+	s.Run("FreshBindingRequiresStructPointer", func() {
+		ctx := digo.NewContainerContext(context.Background())
+		err := digo.BindTransientFresh[digo.Lifecycle](lifecycleFunc(func() {}), ctx)
+		var cloneErr *digo.NotCloneableError
+		s.True(errors.As(err, &cloneErr))
+	})
+
 	s.Run("MissingContextValues", func() {
 		ctx := digo.NewContainerContext(context.Background())
 		db := &mock.MockDB{}
@@ -57,6 +85,52 @@ func (s *ErrorTestSuite) TestErrorCases() {
 		s.True(errors.As(err, &missingErr))
 	})
 
+	s.Run("NonStringRequestID", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", 42)
+		var invalidErr *digo.InvalidContextValueError
+		s.ErrorAs(digo.BindRequestStrict[mock.Database](&mock.MockDB{}, ctx), &invalidErr)
+		s.Equal("request_id", invalidErr.Key)
+		s.Equal("int", invalidErr.Got)
+		s.EqualError(invalidErr, "context value request_id has type int, expected string")
+		s.ErrorAs(digo.BeginRequest(ctx), &invalidErr)
+
+		s.ErrorAs(digo.BindRequest[mock.Database](&mock.MockDB{}, ctx), &invalidErr,
+			"A non-string request_id should be rejected rather than stored under its printed form")
+		s.Equal(0, digo.BindingCount())
+	})
+
+	s.Run("NonStringSessionID", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background()).WithValue("session_id", 42)
+		var invalidErr *digo.InvalidContextValueError
+		s.ErrorAs(digo.BindSession[mock.Database](&mock.MockDB{}, ctx), &invalidErr)
+		s.Equal("session_id", invalidErr.Key)
+		s.Equal(0, digo.BindingCount())
+
+		_, err := digo.ResolveSession[mock.Database](ctx)
+		s.ErrorAs(err, &invalidErr)
+	})
+
+	s.Run("StrictRequestBindingWithoutRequestID", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background())
+		err := digo.BindRequestStrict[mock.Database](&mock.MockDB{}, ctx)
+		var missingErr *digo.MissingContextValueError
+		s.True(errors.As(err, &missingErr))
+		s.Equal("request_id", missingErr.Key)
+
+		// Nothing is registered on failure
+		_, err = digo.ResolveRequest[mock.Database]()
+		var notFoundErr *digo.BindingNotFoundError
+		s.True(errors.As(err, &notFoundErr))
+
+		err = digo.BindRequestStrict[mock.Database](&mock.MockDB{}, ctx.WithValue("request_id", "strict"))
+		s.NoError(err)
+		_, err = digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+	})
+
 	s.Run("RecoveryAfterFailedBoot", func() {
 		failingDB := &mock.FailingDB{ShouldFail: true}
 		ctx := digo.NewContainerContext(context.Background()).
@@ -79,6 +153,52 @@ func (s *ErrorTestSuite) TestErrorCases() {
 		s.NoError(err)
 	})
 
+	s.Run("BootAllCollectsFailures", func() {
+		digo.Reset()
+		s.NoError(digo.BindSingleton[mock.Database](&mock.FailingDB{ShouldFail: true}))
+		s.NoError(digo.ProvideSingleton[mock.Cache](func(ctx *digo.ContainerContext) (mock.Cache, error) {
+			return nil, fmt.Errorf("cache misconfigured")
+		}))
+		workingDB := &mock.MockDB{}
+		s.NoError(digo.Bind[mock.Database](workingDB).Named("replica").AsSingleton())
+
+		err := digo.BootAll()
+		var multiErr *digo.MultiBootError
+		s.Require().ErrorAs(err, &multiErr)
+		s.Len(multiErr.Errors, 2, "Every failing singleton should be reported")
+		s.Contains(err.Error(), "simulated boot failure")
+		s.Contains(err.Error(), "cache misconfigured")
+		var initErr *digo.InitializationError
+		s.ErrorAs(err, &initErr)
+		s.True(workingDB.IsConnected(), "Services after a failure should still boot")
+	})
+
+	s.Run("BootAndReport", func() {
+		digo.Reset()
+		s.NoError(digo.BindSingleton[mock.Database](&mock.FailingDB{ShouldFail: true}))
+		workingDB := &mock.MockDB{}
+		s.NoError(digo.Bind[mock.Database](workingDB).Named("replica").AsSingleton())
+
+		report, err := digo.BootAndReport()
+		var multiErr *digo.MultiBootError
+		s.Require().ErrorAs(err, &multiErr)
+		s.Len(multiErr.Errors, 1)
+		s.Len(report, 2)
+		s.Contains(report, "mock.Database#replica")
+		s.NoError(report["mock.Database#replica"])
+		var initErr *digo.InitializationError
+		s.ErrorAs(report["mock.Database"], &initErr)
+		s.True(workingDB.IsConnected())
+
+		// Singletons bound after the boot pass are booted and reported too
+		s.NoError(digo.BindSingleton[mock.DeepService3](&mock.DeepImpl3{}))
+		report, err = digo.BootAndReport()
+		s.Error(err)
+		s.Contains(report, "mock.DeepService3")
+		s.NoError(report["mock.DeepService3"])
+		s.Equal([]string{"mock.Database"}, digo.PendingSingletons(), "Only the failing singleton should stay pending")
+	})
+
 	s.Run("CircularDependency", func() {
 		ctx := digo.NewContainerContext(context.Background())
 		err := digo.BindTransient[mock.CircularService1](&mock.CircularImpl1{}, ctx)
@@ -91,8 +211,159 @@ func (s *ErrorTestSuite) TestErrorCases() {
 		s.Error(err)
 		s.Contains(err.Error(), "circular dependency")
 	})
+
+	s.Run("CircularAllowPartial", func() {
+		digo.Reset()
+		defer digo.SetCircularPolicy(digo.CircularError)
+		digo.SetCircularPolicy(digo.CircularAllowPartial)
+		ctx := digo.NewContainerContext(context.Background())
+		impl1 := &mock.CircularImpl1{}
+		s.NoError(digo.BindTransient[mock.CircularService1](impl1, ctx))
+		s.NoError(digo.BindTransient[mock.CircularService2](&mock.CircularImpl2{}, ctx))
+
+		svc1, err := digo.ResolveTransient[mock.CircularService1]()
+		s.NoError(err)
+		s.Same(impl1, svc1)
+		s.Same(impl1, svc1.GetService2().GetService1(), "The cycle should be closed with the partially booted instance")
+	})
+
+	s.Run("SealAfterBoot", func() {
+		digo.Reset()
+		defer digo.SetSealAfterBoot(false)
+		digo.SetSealAfterBoot(true)
+		ctx := digo.NewContainerContext(context.Background())
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}), "Binding before boot is allowed")
+		s.NoError(digo.Boot())
+
+		var sealedErr *digo.BindAfterBootError
+		s.ErrorAs(digo.BindSingleton[mock.Cache](&mock.MockCache{}), &sealedErr)
+		s.Equal("mock.Cache", sealedErr.Type)
+		s.ErrorAs(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx), &sealedErr)
+		s.ErrorAs(digo.ProvideSingleton[mock.Cache](func(ctx *digo.ContainerContext) (mock.Cache, error) {
+			return &mock.MockCache{}, nil
+		}), &sealedErr)
+
+		digo.SetSealAfterBoot(false)
+		s.NoError(digo.BindSingleton[mock.Cache](&mock.MockCache{}))
+	})
+
+	s.Run("Freeze", func() {
+		digo.Reset()
+		defer digo.Unfreeze()
+		db := &mock.MockDB{}
+		s.NoError(digo.BindSingleton[mock.Database](db))
+		digo.Freeze()
+
+		var frozenErr *digo.ContainerFrozenError
+		s.ErrorAs(digo.BindSingleton[mock.Cache](&mock.MockCache{}), &frozenErr)
+		s.ErrorAs(digo.ResetScope(digo.ScopeSingleton), &frozenErr)
+		digo.Reset()
+
+		resolved, err := digo.ResolveSingleton[mock.Database]()
+		s.NoError(err, "Resolutions should proceed while frozen")
+		s.Same(db, resolved, "Reset should be ignored while frozen")
+
+		digo.Unfreeze()
+		s.NoError(digo.BindSingleton[mock.Cache](&mock.MockCache{}))
+	})
+
+	s.Run("SingletonCircularDependency", func() {
+		digo.Reset()
+		s.NoError(digo.BindSingleton[*selfResolving](&selfResolving{}))
+
+		_, err := digo.ResolveSingleton[*selfResolving]()
+		s.Error(err)
+		s.Contains(err.Error(), "circular dependency", "Cold singleton resolution should still detect cycles")
+	})
+
+	s.Run("ErrorWrapper", func() {
+		digo.Reset()
+		digo.SetErrorWrapper(func(err error) error {
+			return &tracedError{TraceID: "trace-42", Err: err}
+		})
+
+		_, err := digo.ResolveSingleton[mock.Database]()
+		var traced *tracedError
+		s.ErrorAs(err, &traced)
+		s.Equal("trace-42", traced.TraceID)
+		var notFound *digo.BindingNotFoundError
+		s.ErrorAs(err, &notFound, "The wrapped error should still match through Unwrap")
+
+		s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		_, err = digo.ResolveSingleton[mock.Database]()
+		s.NoError(err, "Successful resolutions should not be wrapped")
+		s.NoError(digo.Shutdown(true))
+
+		digo.Reset()
+		_, err = digo.ResolveSingleton[mock.Database]()
+		s.False(errors.As(err, &traced), "Reset should remove the error wrapper")
+	})
+
+	s.Run("GenericInstantiationMismatch", func() {
+		digo.Reset()
+		s.NoError(digo.BindSingleton[Repo[accountRecord]](&memoryRepo[accountRecord]{}))
+
+		_, err := digo.ResolveSingleton[Repo[invoiceRecord]]()
+		var mismatch *digo.InstantiationMismatchError
+		s.Require().ErrorAs(err, &mismatch, "A different instantiation should not be reported as not found")
+		s.Equal("digo_test.Repo[github.com/centraunit/digo/services_test_test.invoiceRecord]", mismatch.Requested)
+		s.Equal("digo_test.Repo[github.com/centraunit/digo/services_test_test.accountRecord]", mismatch.Bound)
+		var notFound *digo.BindingNotFoundError
+		s.False(errors.As(err, &notFound))
+
+		_, err = digo.ResolveSingleton[Repo[accountRecord]]()
+		s.NoError(err, "The bound instantiation should still resolve")
+	})
+}
+
+// tracedError attaches a trace ID to a container error
+type tracedError struct {
+	TraceID string
+	Err     error
 }
 
+func (e *tracedError) Error() string { return e.TraceID + ": " + e.Err.Error() }
+
+func (e *tracedError) Unwrap() error { return e.Err }
+
+// selfResolving is a singleton that resolves itself during OnBoot
+type selfResolving struct{}
+
+func (r *selfResolving) OnBoot(ctx *digo.ContainerContext) error {
+	_, err := digo.ResolveSingleton[*selfResolving]()
+	return err
+}
+
+func (r *selfResolving) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// Repo is a generic service bound per record type
+type Repo[T any] interface {
+	digo.Lifecycle
+	Find(id string) (T, bool)
+}
+
+type accountRecord struct{}
+
+type invoiceRecord struct{}
+
+type memoryRepo[T any] struct {
+	records map[string]T
+}
+
+func (r *memoryRepo[T]) Find(id string) (T, bool) {
+	record, ok := r.records[id]
+	return record, ok
+}
+
+func (r *memoryRepo[T]) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (r *memoryRepo[T]) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// lifecycleFunc is a non-struct Lifecycle implementation
+type lifecycleFunc func()
+
+func (f lifecycleFunc) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (f lifecycleFunc) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
 func TestErrorSuite(t *testing.T) {
 	suite.Run(t, new(ErrorTestSuite))
 }
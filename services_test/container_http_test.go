@@ -1,9 +1,13 @@
 package digo_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/centraunit/digo"
 	"github.com/centraunit/digo/mock"
@@ -21,8 +25,9 @@ func (s *HTTPTestSuite) SetupTest() {
 // Middleware to handle container lifecycle
 func containerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Create request context with unique ID
-		ctx := digo.NewContainerContext(r.Context()).
+		// Create request context with unique ID; EndRequest owns cleanup, so
+		// cancellation of the HTTP request is not propagated to the instances
+		ctx := digo.NewContainerContext(context.WithoutCancel(r.Context())).
 			WithValue("request_id", r.Header.Get("X-Request-ID"))
 
 		// Boot container before request
@@ -31,18 +36,24 @@ func containerMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Call next handler
-		next.ServeHTTP(w, r.WithContext(ctx))
-
-		// Shutdown after request (keep singletons)
-		if err := digo.Shutdown(false); err != nil {
-			http.Error(w, "Container shutdown failed", http.StatusInternalServerError)
+		// Resolve request-scoped services for this request until it ends
+		if err := digo.BeginRequest(ctx); err != nil {
+			http.Error(w, "Request scope failed", http.StatusBadRequest)
 			return
 		}
+		defer digo.EndRequest(r.Header.Get("X-Request-ID"))
+
+		// Call next handler
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
 func (s *HTTPTestSuite) TestRequestScopeLifecycle() {
+	// The first request stays open until the second has tried to resolve, so
+	// its binding is live while the second request runs
+	bound := make(chan struct{})
+	resolved := make(chan struct{})
+
 	// Create handlers that use different scopes
 	handler1 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Bind and resolve request-scoped service
@@ -54,13 +65,21 @@ func (s *HTTPTestSuite) TestRequestScopeLifecycle() {
 		s.NoError(err)
 		s.True(instance.(*mock.MockDB).IsConnected())
 
+		close(bound)
+		<-resolved
 		w.WriteHeader(http.StatusOK)
 	})
 
 	handler2 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Try to resolve the same request-scoped service (should be different instance)
+		defer close(resolved)
+		<-bound
+
+		// Try to resolve the same request-scoped service, which req-2 never bound
 		instance, err := digo.ResolveRequest[mock.Database]()
-		s.Error(err) // Should fail as it's a new request
+		var notFound *digo.BindingNotFoundError
+		if s.ErrorAs(err, &notFound, "Another request's instance must not leak into this one") {
+			s.Equal("req-2", notFound.Request)
+		}
 		s.Nil(instance)
 
 		w.WriteHeader(http.StatusOK)
@@ -76,19 +95,167 @@ func (s *HTTPTestSuite) TestRequestScopeLifecycle() {
 	})))
 	defer server.Close()
 
-	// Make first request
-	req1, _ := http.NewRequest("GET", server.URL+"/handler1", nil)
-	req1.Header.Set("X-Request-ID", "req-1")
-	resp1, err := http.DefaultClient.Do(req1)
-	s.NoError(err)
-	s.Equal(http.StatusOK, resp1.StatusCode)
+	var wg sync.WaitGroup
+	for _, route := range []struct{ path, id string }{{"/handler1", "req-1"}, {"/handler2", "req-2"}} {
+		wg.Add(1)
+		go func(path, id string) {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL+path, nil)
+			req.Header.Set("X-Request-ID", id)
+			resp, err := http.DefaultClient.Do(req)
+			s.NoError(err)
+			s.Equal(http.StatusOK, resp.StatusCode)
+		}(route.path, route.id)
+	}
+	wg.Wait()
+}
 
-	// Make second request
-	req2, _ := http.NewRequest("GET", server.URL+"/handler2", nil)
-	req2.Header.Set("X-Request-ID", "req-2")
-	resp2, err := http.DefaultClient.Do(req2)
+func (s *HTTPTestSuite) TestRequestScopeWithoutBeginRequest() {
+	bound := make(chan struct{})
+	resolved := make(chan struct{})
+
+	// Handlers bind with their own request context but never call BeginRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := digo.NewContainerContext(context.WithoutCancel(r.Context())).
+			WithValue("request_id", r.Header.Get("X-Request-ID"))
+		if r.URL.Path == "/bind" {
+			db := &mock.MockDB{}
+			s.NoError(digo.BindRequest[mock.Database](db, ctx))
+			instance, err := digo.ResolveRequest[mock.Database]()
+			s.NoError(err)
+			s.Same(db, instance, "The binding goroutine should resolve its own instance")
+			close(bound)
+			<-resolved
+		} else {
+			defer close(resolved)
+			<-bound
+			instance, err := digo.ResolveRequest[mock.Database]()
+			var notFound *digo.BindingNotFoundError
+			s.ErrorAs(err, &notFound, "Another request's instance must not leak into this one")
+			s.Nil(instance)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for _, route := range []struct{ path, id string }{{"/bind", "req-1"}, {"/resolve", "req-2"}} {
+		wg.Add(1)
+		go func(path, id string) {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL+path, nil)
+			req.Header.Set("X-Request-ID", id)
+			resp, err := http.DefaultClient.Do(req)
+			s.NoError(err)
+			s.Equal(http.StatusOK, resp.StatusCode)
+		}(route.path, route.id)
+	}
+	wg.Wait()
+}
+
+func (s *HTTPTestSuite) TestRequestInstancesPerRequestID() {
+	// The template is bound once, without a request_id
+	s.NoError(digo.BindRequest[mock.Database](&mock.MockDB{}, digo.NewContainerContext(context.Background())))
+
+	var mu sync.Mutex
+	seen := make(map[string]*mock.MockDB)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first, err := digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+		second, err := digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+		s.Same(first, second, "Resolving twice within a request should return the same instance")
+
+		db := first.(*mock.MockDB)
+		s.True(db.IsConnected())
+		s.Equal(r.Header.Get("X-Request-ID"), db.RequestID)
+
+		mu.Lock()
+		seen[db.RequestID] = db
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(containerMiddleware(handler))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"req-1", "req-2"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			req.Header.Set("X-Request-ID", id)
+			resp, err := http.DefaultClient.Do(req)
+			s.NoError(err)
+			s.Equal(http.StatusOK, resp.StatusCode)
+		}(id)
+	}
+	wg.Wait()
+
+	s.Len(seen, 2)
+	s.NotSame(seen["req-1"], seen["req-2"], "Each request should get its own instance")
+	s.False(seen["req-1"].IsConnected(), "Ending the request should shut its instance down")
+	s.False(seen["req-2"].IsConnected())
+}
+
+// fanoutDB counts its boots and boots slowly enough for resolutions to overlap
+type fanoutDB struct {
+	mock.MockDB
+	boots atomic.Int32
+}
+
+func (f *fanoutDB) OnBoot(ctx *digo.ContainerContext) error {
+	f.boots.Add(1)
+	time.Sleep(5 * time.Millisecond)
+	return f.MockDB.OnBoot(ctx)
+}
+
+func (s *HTTPTestSuite) TestRequestScopeInSpawnedGoroutines() {
+	s.NoError(digo.BindRequest[mock.Database](&fanoutDB{}, digo.NewContainerContext(context.Background())))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context().(*digo.ContainerContext)
+		unscoped := make(chan error, 1)
+		go func() {
+			_, err := digo.ResolveRequest[mock.Database]()
+			unscoped <- err
+		}()
+		s.Error(<-unscoped, "A spawned goroutine is not associated with the request by default")
+
+		const workers = 4
+		resolved := make([]mock.Database, workers)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				digo.WithRequestScope(ctx, func() {
+					db, err := digo.ResolveRequest[mock.Database]()
+					s.NoError(err)
+					resolved[i] = db
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		own, err := digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+		for _, db := range resolved {
+			s.Same(own, db, "Spawned goroutines should see the handler's request instance")
+		}
+		s.Equal(int32(1), own.(*fanoutDB).boots.Load(), "The shared request instance should boot once")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(containerMiddleware(handler))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("X-Request-ID", "req-fanout")
+	resp, err := http.DefaultClient.Do(req)
 	s.NoError(err)
-	s.Equal(http.StatusOK, resp2.StatusCode)
+	s.Equal(http.StatusOK, resp.StatusCode)
 }
 
 func (s *HTTPTestSuite) TestTransientScopeLifecycle() {
@@ -172,6 +339,67 @@ func (s *HTTPTestSuite) TestSingletonScopeLifecycle() {
 	s.Equal(http.StatusOK, resp2.StatusCode)
 }
 
+// Middleware is a Lifecycle service that wraps an http.Handler
+type Middleware interface {
+	digo.Lifecycle
+	Wrap(next http.Handler) http.Handler
+}
+
+// headerMiddleware appends its name to the X-Chain response header once booted
+type headerMiddleware struct {
+	name   string
+	booted bool
+}
+
+func (m *headerMiddleware) OnBoot(ctx *digo.ContainerContext) error {
+	m.booted = true
+	return nil
+}
+
+func (m *headerMiddleware) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (m *headerMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.booted {
+			w.Header().Add("X-Chain", m.name)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// composeChain folds the middleware chain around handler, so the first
+// registered middleware runs outermost
+func composeChain(handler http.Handler) (http.Handler, error) {
+	chain, err := digo.ResolveChain[Middleware]()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i].Wrap(handler)
+	}
+	return handler, nil
+}
+
+func (s *HTTPTestSuite) TestMiddlewareChain() {
+	s.NoError(digo.BindSingleton[Middleware](&headerMiddleware{name: "recover"}))
+	s.NoError(digo.Bind[Middleware](&headerMiddleware{name: "auth"}).Named("auth").AsSingleton())
+	s.NoError(digo.Bind[Middleware](&headerMiddleware{name: "log"}).Named("log").AsSingleton())
+
+	handler, err := composeChain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	s.Require().NoError(err)
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal([]string{"recover", "auth", "log"}, resp.Header.Values("X-Chain"), "Middleware should be booted and run in registration order")
+}
+
 func TestHTTPSuite(t *testing.T) {
 	suite.Run(t, new(HTTPTestSuite))
 }
@@ -0,0 +1,69 @@
+package digo_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InterceptorTestSuite struct {
+	suite.Suite
+}
+
+func (s *InterceptorTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+func (s *InterceptorTestSuite) TestInterceptedResolution() {
+	legacy := &mock.MockDB{}
+	databaseType := reflect.TypeOf((*mock.Database)(nil)).Elem()
+	digo.SetResolveInterceptor(func(t reflect.Type, scope digo.Scope) (digo.Lifecycle, bool) {
+		if t == databaseType && scope == digo.ScopeSingleton {
+			return legacy, true
+		}
+		return nil, false
+	})
+
+	// No binding is needed for intercepted types, and OnBoot is skipped
+	db, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(legacy, db)
+	s.False(legacy.IsConnected())
+
+	// Other lookups fall through to the container
+	ctx := digo.NewContainerContext(context.Background())
+	bound := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](bound, ctx))
+	db, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Same(bound, db)
+}
+
+func (s *InterceptorTestSuite) TestInterceptorTypeMismatch() {
+	digo.SetResolveInterceptor(func(t reflect.Type, scope digo.Scope) (digo.Lifecycle, bool) {
+		return &mock.MockCache{}, true
+	})
+
+	_, err := digo.ResolveRequest[mock.Database]()
+	var mismatchErr *digo.TypeMismatchError
+	s.ErrorAs(err, &mismatchErr)
+}
+
+func (s *InterceptorTestSuite) TestResetRemovesInterceptor() {
+	digo.SetResolveInterceptor(func(t reflect.Type, scope digo.Scope) (digo.Lifecycle, bool) {
+		return &mock.MockDB{}, true
+	})
+	digo.Reset()
+
+	_, err := digo.ResolveSingleton[mock.Database]()
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound)
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
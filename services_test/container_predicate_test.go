@@ -0,0 +1,192 @@
+package digo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/centraunit/digo"
+	"github.com/centraunit/digo/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PredicateTestSuite struct {
+	suite.Suite
+}
+
+func (s *PredicateTestSuite) SetupTest() {
+	digo.Reset()
+}
+
+// envIs matches when the binding context carries the given env value
+func envIs(env string) digo.MatchPredicate {
+	return func(ctx *digo.ContainerContext) (digo.Lifecycle, bool, error) {
+		return nil, ctx.Value("env") == env, nil
+	}
+}
+
+func (s *PredicateTestSuite) TestChainedPredicates() {
+	s.Run("FirstMatchWins", func() {
+		ctx := digo.NewContainerContext(context.Background()).
+			WithValue("env", "staging")
+		prodDB := &mock.MockDB{}
+		stagingDB := &mock.MockDB{}
+		fallbackDB := &mock.MockDB{}
+
+		s.NoError(digo.BindTransientWhen[mock.Database](prodDB, ctx, envIs("prod")))
+		s.NoError(digo.BindTransientWhen[mock.Database](stagingDB, ctx, envIs("staging")))
+		s.NoError(digo.BindTransientWhen[mock.Database](fallbackDB, ctx, nil))
+
+		instance, err := digo.ResolveTransient[mock.Database]()
+		s.NoError(err)
+		s.Same(stagingDB, instance)
+	})
+
+	s.Run("NoCandidateMatches", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background()).
+			WithValue("env", "dev")
+		s.NoError(digo.BindTransientWhen[mock.Database](&mock.MockDB{}, ctx, envIs("prod")))
+
+		_, err := digo.ResolveTransient[mock.Database]()
+		var noMatch *digo.NoMatchingBindingError
+		s.True(errors.As(err, &noMatch))
+	})
+
+	s.Run("PredicateErrorStopsChain", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background())
+		s.NoError(digo.BindTransientWhen[mock.Database](&mock.MockDB{}, ctx, func(*digo.ContainerContext) (digo.Lifecycle, bool, error) {
+			return nil, false, fmt.Errorf("config unavailable")
+		}))
+		s.NoError(digo.BindTransientWhen[mock.Database](&mock.MockDB{}, ctx, nil))
+
+		_, err := digo.ResolveTransient[mock.Database]()
+		var predErr *digo.PredicateError
+		s.True(errors.As(err, &predErr))
+	})
+
+	s.Run("RequestScope", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background()).
+			WithValue("env", "prod").
+			WithValue("request_id", "req-1")
+		prodDB := &mock.MockDB{}
+		s.NoError(digo.BindRequestWhen[mock.Database](&mock.MockDB{}, ctx, envIs("dev")))
+		s.NoError(digo.BindRequestWhen[mock.Database](prodDB, ctx, envIs("prod")))
+
+		instance, err := digo.ResolveRequest[mock.Database]()
+		s.NoError(err)
+		s.Same(prodDB, instance)
+		s.True(prodDB.IsConnected())
+	})
+}
+
+func (s *PredicateTestSuite) TestTypedPredicate() {
+	ctx := digo.NewContainerContext(context.Background()).WithValue("env", "prod")
+	prodDB := &mock.MockDB{}
+	predicate := func(ctx *digo.ContainerContext) (mock.Database, error) {
+		if ctx.Value("env") != "prod" {
+			return nil, errors.New("unsupported env")
+		}
+		return prodDB, nil
+	}
+	s.NoError(digo.BindTransientWhenTyped[mock.Database](&mock.MockDB{}, ctx, predicate))
+	s.True(digo.HasPredicate[mock.Database](digo.ScopeTransient))
+
+	db, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Same(prodDB, db)
+
+	digo.Reset()
+	devCtx := digo.NewContainerContext(context.Background()).WithValue("env", "dev")
+	s.NoError(digo.Bind[mock.Database](&mock.MockDB{}).WithContext(devCtx).WhenTyped(predicate).AsTransient())
+	_, err = digo.ResolveTransient[mock.Database]()
+	var predicateErr *digo.PredicateError
+	s.ErrorAs(err, &predicateErr)
+	s.EqualError(errors.Unwrap(err), "unsupported env")
+
+	digo.Reset()
+	requestCtx := digo.NewContainerContext(context.Background()).WithValue("env", "prod").WithValue("request_id", "req-1")
+	s.NoError(digo.BindRequestWhenTyped[mock.Database](&mock.MockDB{}, requestCtx, predicate))
+	db, err = digo.ResolveRequest[mock.Database]()
+	s.NoError(err)
+	s.Same(prodDB, db)
+
+	digo.Reset()
+	s.NoError(digo.BindTransientWhenTyped[mock.Database](&mock.MockDB{}, ctx, func(ctx *digo.ContainerContext) (mock.Database, error) {
+		return nil, nil
+	}))
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.ErrorAs(err, &predicateErr, "A typed predicate returning nil should be reported")
+	var nilErr *digo.NilServiceError
+	s.ErrorAs(digo.BindTransientWhenTyped[mock.Database](&mock.MockDB{}, ctx, nil), &nilErr)
+}
+
+func (s *PredicateTestSuite) TestSetPredicate() {
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(digo.SetPredicate[mock.Database](digo.ScopeTransient, nil), &notFound, "Unbound types cannot get a predicate")
+
+	ctx := digo.NewContainerContext(context.Background())
+	baseDB := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](baseDB, ctx))
+	s.False(digo.HasPredicate[mock.Database](digo.ScopeTransient))
+
+	replicaDB := &mock.MockDB{}
+	s.NoError(digo.SetPredicate[mock.Database](digo.ScopeTransient, func(ctx *digo.ContainerContext) (digo.Lifecycle, error) {
+		return replicaDB, nil
+	}))
+	s.True(digo.HasPredicate[mock.Database](digo.ScopeTransient))
+	db, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Same(replicaDB, db)
+
+	s.NoError(digo.SetPredicate[mock.Database](digo.ScopeTransient, nil))
+	db, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Same(baseDB, db, "A nil predicate should make the binding unconditional")
+
+	s.NoError(digo.BindSingleton[mock.Cache](&mock.MockCache{}))
+	var scopeErr *digo.InvalidScopeError
+	s.ErrorAs(digo.SetPredicate[mock.Cache](digo.ScopeSingleton, nil), &scopeErr)
+}
+
+func (s *PredicateTestSuite) TestPredicateCachedPerRequest() {
+	evaluations := 0
+	primaryDB, replicaDB := &mock.MockDB{}, &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](primaryDB, digo.NewContainerContext(context.Background()), func(ctx *digo.ContainerContext) (digo.Lifecycle, error) {
+		evaluations++
+		if evaluations%2 == 0 {
+			return replicaDB, nil
+		}
+		return primaryDB, nil
+	}))
+
+	_, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Equal(2, evaluations, "Predicates should run on every resolution outside of a request")
+
+	reqCtx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
+	s.NoError(digo.BeginRequest(reqCtx))
+	first, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	second, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Equal(3, evaluations, "Predicates should run once per request")
+	s.Same(first, second, "The chosen instance should be reused within the request")
+	s.NoError(digo.EndRequest("req-1"))
+
+	s.NoError(digo.BeginRequest(reqCtx))
+	defer digo.EndRequest("req-1")
+	third, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Equal(4, evaluations, "EndRequest should clear the cached choice")
+	s.NotSame(first, third)
+}
+
+func TestPredicateSuite(t *testing.T) {
+	suite.Run(t, new(PredicateTestSuite))
+}
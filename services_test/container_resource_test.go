@@ -2,7 +2,11 @@ package digo_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/centraunit/digo"
 	"github.com/centraunit/digo/mock"
@@ -32,6 +36,61 @@ func (s *ResourceTestSuite) TestTransientScope() {
 	s.True(instance2.(*mock.MockDB).IsConnected())
 }
 
+func (s *ResourceTestSuite) TestFreshTransientScope() {
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "fresh")
+	prototype := &mock.MockDB{}
+	err := digo.BindTransientFresh[mock.Database](prototype, ctx)
+	s.NoError(err)
+
+	instance1, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	instance2, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+
+	s.NotSame(instance1, instance2)
+	s.NotSame(prototype, instance1)
+	s.True(instance1.(*mock.MockDB).IsConnected(), "Earlier instances must not be shut down")
+	s.True(instance2.(*mock.MockDB).IsConnected())
+	s.False(prototype.IsConnected(), "The prototype itself is never booted")
+	s.Equal("fresh", instance2.(*mock.MockDB).RequestID)
+}
+
+func (s *ResourceTestSuite) TestTransientStrategy() {
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "strategy")
+	bound := &mock.MockDB{RequestID: "configured"}
+	s.NoError(digo.BindTransient[mock.Database](bound, ctx))
+
+	digo.SetTransientStrategy(digo.NewViaReflect)
+	instance1, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	instance2, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.NotSame(instance1, instance2)
+	s.NotSame(bound, instance1)
+	s.True(instance1.(*mock.MockDB).IsConnected(), "Earlier instances must not be shut down")
+	s.Equal("strategy", instance2.(*mock.MockDB).RequestID, "OnBoot should initialize the new instance")
+	s.False(bound.IsConnected(), "The bound instance is never booted")
+
+	digo.SetTransientStrategy(digo.NewViaFactory)
+	_, err = digo.ResolveTransient[mock.Database]()
+	var factoryErr *digo.FactoryRequiredError
+	s.ErrorAs(err, &factoryErr)
+	s.NoError(digo.ProvideTransient[mock.Cache](func(ctx *digo.ContainerContext, args ...any) (mock.Cache, error) {
+		return &mock.MockCache{}, nil
+	}, ctx))
+	s.NoError(digo.ProvideTransient[mock.Database](func(ctx *digo.ContainerContext, args ...any) (mock.Database, error) {
+		return &mock.MockDB{}, nil
+	}, ctx))
+	_, err = digo.ResolveTransient[mock.Cache]()
+	s.NoError(err, "Factory bindings resolve under NewViaFactory")
+
+	digo.Reset()
+	s.NoError(digo.BindTransient[mock.Database](bound, ctx))
+	reused, err := digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	s.Same(bound, reused, "Reset should restore ReuseAndReinit")
+}
+
 func (s *ResourceTestSuite) TestRequestScope() {
 	db := &mock.MockDB{}
 	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
@@ -58,6 +117,150 @@ func (s *ResourceTestSuite) TestRequestScope() {
 	s.True(instance3.(*mock.MockDB).IsConnected())
 }
 
+func (s *ResourceTestSuite) TestResolveRequestOrNew() {
+	var built atomic.Int32
+	factory := func(ctx *digo.ContainerContext) (mock.Database, error) {
+		built.Add(1)
+		return &mock.MockDB{}, nil
+	}
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
+
+	var wg sync.WaitGroup
+	instances := make([]mock.Database, 8)
+	for i := range instances {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			db, err := digo.ResolveRequestOrNew[mock.Database](factory, ctx)
+			s.NoError(err)
+			instances[i] = db
+		}(i)
+	}
+	wg.Wait()
+	s.Equal(int32(1), built.Load(), "The factory should run once per request")
+	for _, db := range instances {
+		s.Same(instances[0], db)
+	}
+	s.True(instances[0].(*mock.MockDB).IsConnected(), "OnBoot should be called")
+
+	ctx2 := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-2")
+	db2, err := digo.ResolveRequestOrNew[mock.Database](factory, ctx2)
+	s.NoError(err)
+	s.NotSame(instances[0], db2, "Each request should get its own instance")
+
+	s.NoError(digo.EndRequest("req-1"))
+	s.False(instances[0].(*mock.MockDB).IsConnected())
+	db3, err := digo.ResolveRequestOrNew[mock.Database](factory, ctx)
+	s.NoError(err)
+	s.NotSame(instances[0], db3, "An ended request should be constructed again")
+
+	_, err = digo.ResolveRequestOrNew[mock.Database](factory, digo.NewContainerContext(context.Background()))
+	var missing *digo.MissingContextValueError
+	s.ErrorAs(err, &missing)
+
+	_, err = digo.ResolveRequestOrNew[mock.Cache](func(ctx *digo.ContainerContext) (mock.Cache, error) {
+		return nil, fmt.Errorf("cache unavailable")
+	}, ctx)
+	var initErr *digo.InitializationError
+	s.ErrorAs(err, &initErr)
+}
+
+func (s *ResourceTestSuite) TestResolveAfterEndRequest() {
+	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-9")
+	_, err := digo.ResolveRequest[mock.Database]()
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound, "A request that never existed is not found")
+
+	s.NoError(digo.BeginRequest(ctx))
+	s.NoError(digo.BindRequest[mock.Database](&mock.MockDB{}, ctx))
+	_, err = digo.ResolveRequest[mock.Database]()
+	s.NoError(err)
+
+	// A goroutine spawned for the request outlives it
+	ended := make(chan struct{})
+	lateErr := make(chan error, 1)
+	go digo.WithRequestScope(ctx, func() {
+		<-ended
+		_, err := digo.ResolveRequest[mock.Database]()
+		lateErr <- err
+	})
+	s.NoError(digo.EndRequest("req-9"))
+	close(ended)
+
+	var endedErr *digo.RequestEndedError
+	s.ErrorAs(<-lateErr, &endedErr)
+	s.Equal("req-9", endedErr.RequestID)
+	_, err = digo.ResolveRequest[mock.Database]()
+	s.ErrorAs(err, &endedErr, "The goroutine that ended the request should see it ended")
+	s.EqualError(err, "request req-9 has ended")
+
+	// Beginning the request again makes it live
+	s.NoError(digo.BeginRequest(ctx))
+	defer digo.EndRequest("req-9")
+	_, err = digo.ResolveRequest[mock.Database]()
+	s.ErrorAs(err, &notFound)
+}
+
+func (s *ResourceTestSuite) TestSessionScope() {
+	cart := &mock.MockDB{}
+	sessionCtx := digo.NewContainerContext(context.Background()).WithValue("session_id", "cart-1")
+	s.NoError(digo.BindSession[mock.Database](cart, sessionCtx))
+
+	var missing *digo.MissingContextValueError
+	s.ErrorAs(digo.BindSession[mock.Database](&mock.MockDB{}, digo.NewContainerContext(context.Background())), &missing)
+	_, err := digo.ResolveSession[mock.Database](digo.NewContainerContext(context.Background()))
+	s.ErrorAs(err, &missing)
+
+	// Each request of the session sees the same instance
+	for _, requestID := range []string{"req-1", "req-2"} {
+		requestCtx := sessionCtx.WithValue("request_id", requestID)
+		db, err := digo.ResolveSession[mock.Database](requestCtx)
+		s.NoError(err)
+		s.Same(cart, db)
+		s.NoError(digo.Shutdown(false), "Ending a request should keep the session")
+	}
+	s.True(cart.IsConnected())
+
+	otherCtx := digo.NewContainerContext(context.Background()).WithValue("session_id", "cart-2")
+	_, err = digo.ResolveSession[mock.Database](otherCtx)
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound, "Sessions should not share instances")
+
+	s.NoError(digo.EndSession("cart-1"))
+	s.False(cart.IsConnected(), "EndSession should shut the instance down")
+	_, err = digo.ResolveSession[mock.Database](sessionCtx)
+	s.ErrorAs(err, &notFound)
+}
+
+func (s *ResourceTestSuite) TestRequestCancellationCleanup() {
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := digo.NewContainerContext(parent).WithValue("request_id", "req-cancel")
+
+	db := &releaseProbe{}
+	s.NoError(digo.BindRequest[mock.Database](db, ctx))
+	_, err := digo.ResolveRequest[mock.Database]()
+	s.NoError(err)
+	s.True(db.IsConnected())
+
+	cancel()
+	s.Eventually(func() bool {
+		_, err := digo.ResolveRequest[mock.Database]()
+		return err != nil && db.released.Load()
+	}, time.Second, time.Millisecond, "Cancelled request binding should be shut down and evicted")
+}
+
+// releaseProbe records its shutdown, which may run on another goroutine
+type releaseProbe struct {
+	mock.MockDB
+	released atomic.Bool
+}
+
+func (p *releaseProbe) OnShutdown(ctx *digo.ContainerContext) error {
+	p.released.Store(true)
+	return nil
+}
+
 func (s *ResourceTestSuite) TestMemoryCleanup() {
 	db := &mock.MockDB{}
 	ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", "req-1")
@@ -75,6 +278,33 @@ func (s *ResourceTestSuite) TestMemoryCleanup() {
 	s.Error(err, "Should not be able to resolve after Reset")
 }
 
+func (s *ResourceTestSuite) TestShutdownCleansEveryRequest() {
+	dbs := []*mock.MockDB{{}, {}}
+	var wg sync.WaitGroup
+	for i, db := range dbs {
+		wg.Add(1)
+		go func(i int, db *mock.MockDB) {
+			defer wg.Done()
+			ctx := digo.NewContainerContext(context.Background()).WithValue("request_id", fmt.Sprintf("req-%d", i))
+			s.NoError(digo.BindRequest[mock.Database](db, ctx))
+		}(i, db)
+	}
+	wg.Wait()
+
+	s.NoError(digo.Boot())
+	for _, db := range dbs {
+		s.True(db.IsConnected(), "Every request instance should be booted")
+	}
+
+	s.NoError(digo.Shutdown(false))
+	for _, db := range dbs {
+		s.False(db.IsConnected(), "Shutdown(false) should call OnShutdown on every request instance")
+	}
+
+	_, err := digo.ResolveRequest[mock.Database]()
+	s.Error(err, "Request bindings should be removed after Shutdown(false)")
+}
+
 func (s *ResourceTestSuite) TestLifecycleCleanup() {
 	// Test regular shutdown (keeping singletons)
 	s.Run("RegularShutdown", func() {
@@ -136,6 +366,257 @@ func (s *ResourceTestSuite) TestLifecycleCleanup() {
 	})
 }
 
+// bootCounter records how many times a service has been booted
+type bootCounter struct {
+	boots int
+}
+
+func (b *bootCounter) OnBoot(ctx *digo.ContainerContext) error {
+	b.boots++
+	return nil
+}
+
+func (b *bootCounter) OnShutdown(ctx *digo.ContainerContext) error {
+	return nil
+}
+
+func (b *bootCounter) Boots() int {
+	return b.boots
+}
+
+type CountedService interface {
+	digo.Lifecycle
+	Boots() int
+}
+
+type ConfigService interface {
+	CountedService
+	IsConfig()
+}
+
+type configService struct {
+	bootCounter
+}
+
+func (c *configService) IsConfig() {}
+
+type ReloadableService interface {
+	CountedService
+	Config() ConfigService
+}
+
+type reloadableService struct {
+	bootCounter
+	config ConfigService
+}
+
+func (r *reloadableService) OnBoot(ctx *digo.ContainerContext) error {
+	r.boots++
+	config, err := digo.ResolveSingleton[ConfigService]()
+	r.config = config
+	return err
+}
+
+func (r *reloadableService) Config() ConfigService {
+	return r.config
+}
+
+func (s *ResourceTestSuite) TestResolveFresh() {
+	config := &configService{}
+	reloadable := &reloadableService{}
+	unrelated := &bootCounter{}
+	s.NoError(digo.BindSingleton[ConfigService](config))
+	s.NoError(digo.BindSingleton[ReloadableService](reloadable))
+	s.NoError(digo.BindSingleton[CountedService](unrelated))
+
+	instance, err := digo.ResolveSingleton[ReloadableService]()
+	s.NoError(err)
+	s.Same(config, instance.Config())
+	_, err = digo.ResolveSingleton[CountedService]()
+	s.NoError(err)
+	s.Equal(1, reloadable.Boots())
+	s.Equal(1, config.Boots())
+
+	// A fresh resolve re-boots the target and its dependencies only
+	instance, err = digo.ResolveFresh[ReloadableService]()
+	s.NoError(err)
+	s.Same(reloadable, instance)
+	s.Equal(2, reloadable.Boots())
+	s.Equal(2, config.Boots())
+	s.Equal(1, unrelated.Boots())
+
+	// Regular resolution uses the cache again
+	_, err = digo.ResolveSingleton[ReloadableService]()
+	s.NoError(err)
+	s.Equal(2, reloadable.Boots())
+}
+
+// closingService closes a channel on shutdown and panics if shut down twice
+type closingService struct {
+	done chan struct{}
+}
+
+func (c *closingService) OnBoot(ctx *digo.ContainerContext) error {
+	c.done = make(chan struct{})
+	return nil
+}
+
+func (c *closingService) OnShutdown(ctx *digo.ContainerContext) error {
+	close(c.done)
+	return nil
+}
+
+func (s *ResourceTestSuite) TestShutdownRunsOncePerBoot() {
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindTransient[digo.Lifecycle](&closingService{}, ctx))
+	s.NoError(digo.BindSingleton[*closingService](&closingService{}))
+
+	// Resolving twice shuts the first boot down before reuse
+	_, err := digo.ResolveTransient[digo.Lifecycle]()
+	s.NoError(err)
+	_, err = digo.ResolveTransient[digo.Lifecycle]()
+	s.NoError(err)
+
+	s.NotPanics(func() {
+		s.NoError(digo.Shutdown(false))
+		// The singleton was never booted and must not be shut down
+		s.NoError(digo.Shutdown(true))
+	})
+}
+
+// queryService is a transient built with a per-call SQL string
+type queryService struct {
+	sql    string
+	booted bool
+}
+
+func (q *queryService) OnBoot(ctx *digo.ContainerContext) error {
+	q.booted = true
+	return nil
+}
+
+func (q *queryService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *ResourceTestSuite) TestResetScope() {
+	singleton := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](singleton))
+	transient := &mock.MockDB{}
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindTransient[mock.Database](transient, ctx))
+	request := &mock.MockDB{}
+	s.NoError(digo.BindRequest[mock.Database](request, ctx.WithValue("request_id", "req-1")))
+
+	_, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	_, err = digo.ResolveTransient[mock.Database]()
+	s.NoError(err)
+	_, err = digo.ResolveRequest[mock.Database]()
+	s.NoError(err)
+
+	s.NoError(digo.ResetScope(digo.ScopeTransient))
+	s.False(transient.IsConnected(), "Initialized transients should be shut down")
+	s.False(digo.IsBound[mock.Database](digo.ScopeTransient))
+	s.True(digo.IsBound[mock.Database](digo.ScopeRequest))
+
+	s.NoError(digo.ResetScope(digo.ScopeRequest))
+	s.False(request.IsConnected())
+	s.False(digo.IsBound[mock.Database](digo.ScopeRequest))
+
+	s.True(singleton.IsConnected(), "Singletons should be kept")
+	db, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(singleton, db)
+	s.Equal(0, digo.ResolutionStateCount())
+}
+
+func (s *ResourceTestSuite) TestShutdownHooks() {
+	db := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](db))
+	s.NoError(digo.Boot())
+
+	var order []string
+	digo.OnShutdownHook(func(ctx *digo.ContainerContext) error {
+		order = append(order, "flush")
+		return nil
+	})
+	failing := true
+	digo.OnShutdownHook(func(ctx *digo.ContainerContext) error {
+		s.False(db.IsConnected(), "Hooks should run after services are shut down")
+		if failing {
+			return fmt.Errorf("temp dir busy")
+		}
+		order = append(order, "remove temp dir")
+		return nil
+	})
+
+	err := digo.Shutdown(true)
+	var shutdownErr *digo.ShutdownError
+	s.ErrorAs(err, &shutdownErr)
+	s.Empty(order, "Hooks after a failing hook should not run")
+
+	failing = false
+	s.NoError(digo.Shutdown(true))
+	s.Equal([]string{"remove temp dir", "flush"}, order, "Hooks should run in LIFO order")
+
+	s.NoError(digo.Shutdown(true))
+	s.Len(order, 2, "Hooks should run once")
+}
+
+func (s *ResourceTestSuite) TestShutdownHookUsesContainer() {
+	s.NoError(digo.BindSingleton[mock.Database](&mock.MockDB{}))
+	var count int
+	digo.OnShutdownHook(func(ctx *digo.ContainerContext) error {
+		count = digo.BindingCount()
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- digo.Shutdown(true) }()
+	select {
+	case err := <-done:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.FailNow("A hook calling the container should not deadlock Shutdown")
+	}
+	s.Equal(1, count)
+}
+
+func (s *ResourceTestSuite) TestResolveTransientWith() {
+	ctx := digo.NewContainerContext(context.Background())
+	err := digo.ProvideTransient[*queryService](func(ctx *digo.ContainerContext, args ...any) (*queryService, error) {
+		if len(args) == 0 {
+			return &queryService{}, nil
+		}
+		sql, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("expected SQL string, got %T", args[0])
+		}
+		return &queryService{sql: sql}, nil
+	}, ctx)
+	s.NoError(err)
+
+	first, err := digo.ResolveTransientWith[*queryService]("SELECT 1")
+	s.NoError(err)
+	second, err := digo.ResolveTransientWith[*queryService]("SELECT 2")
+	s.NoError(err)
+	s.Equal("SELECT 1", first.sql)
+	s.Equal("SELECT 2", second.sql)
+	s.True(first.booted)
+
+	plain, err := digo.ResolveTransient[*queryService]()
+	s.NoError(err)
+	s.Empty(plain.sql)
+
+	_, err = digo.ResolveTransientWith[*queryService](42)
+	var initErr *digo.InitializationError
+	s.ErrorAs(err, &initErr)
+
+	s.NoError(digo.BindTransient[mock.Database](&mock.MockDB{}, ctx))
+	_, err = digo.ResolveTransientWith[mock.Database]("arg")
+	var argsErr *digo.ArgumentsNotSupportedError
+	s.ErrorAs(err, &argsErr)
+}
+
 func TestResourceSuite(t *testing.T) {
 	suite.Run(t, new(ResourceTestSuite))
 }
@@ -2,7 +2,10 @@ package digo_test
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/centraunit/digo"
 	"github.com/centraunit/digo/mock"
@@ -45,4 +48,537 @@ func TestContainerSingleton(t *testing.T) {
 		assert.NoError(t, err2)
 		assert.Same(t, instance1, instance2, "Singleton should maintain state")
 	})
+
+	t.Run("PreInitializedSingleton", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		db := &mock.FailingDB{ShouldFail: true}
+		err := digo.BindSingletonInitialized[mock.Database](db)
+		assert.NoError(t, err)
+
+		// OnBoot would fail, so neither Boot nor resolution may call it
+		assert.NoError(t, digo.Boot())
+		instance, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, db, instance)
+		assert.False(t, db.IsConnected())
+
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("PeekDoesNotBoot", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		_, _, err := digo.PeekSingleton[mock.Database]()
+		var notFoundErr *digo.BindingNotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+
+		db := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](db))
+
+		instance, ok, err := digo.PeekSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, instance)
+		assert.False(t, db.IsConnected(), "Peek must not trigger OnBoot")
+
+		_, err = digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+
+		instance, ok, err = digo.PeekSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Same(t, db, instance)
+	})
+
+	t.Run("ProvidedSingleton", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		calls := 0
+		db := &mock.MockDB{}
+		err := digo.ProvideSingleton[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+			calls++
+			return db, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, calls, "Provider must run lazily")
+
+		instance1, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		instance2, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, db, instance1)
+		assert.Same(t, instance1, instance2)
+		assert.Equal(t, 1, calls)
+		assert.True(t, db.IsConnected(), "Provided instance should be booted")
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("ProvidedSingletonDuringBoot", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		calls := 0
+		err := digo.ProvideSingleton[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+			calls++
+			return &mock.MockDB{}, nil
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, digo.Boot())
+		assert.Equal(t, 1, calls)
+
+		_, err = digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		err := digo.ProvideSingleton[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+			return nil, errors.New("dsn missing")
+		})
+		assert.NoError(t, err)
+
+		_, err = digo.ResolveSingleton[mock.Database]()
+		var initErr *digo.InitializationError
+		assert.ErrorAs(t, err, &initErr)
+		assert.Contains(t, err.Error(), "dsn missing")
+	})
+
+	t.Run("ResolveConcrete", func(t *testing.T) {
+		digo.Shutdown(true)
+		db := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](db))
+
+		concrete, err := digo.ResolveSingletonConcrete[mock.Database, mock.MockDB]()
+		assert.NoError(t, err)
+		assert.Same(t, db, concrete)
+		assert.True(t, concrete.IsConnected())
+
+		_, err = digo.ResolveSingletonConcrete[mock.Database, mock.FailingDB]()
+		var mismatchErr *digo.TypeMismatchError
+		assert.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, "*mock.FailingDB", mismatchErr.Expected)
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("ResolveOr", func(t *testing.T) {
+		digo.Shutdown(true)
+		fallback := &mock.MockDB{}
+		assert.Same(t, fallback, digo.ResolveSingletonOr[mock.Database](fallback), "A missing binding should yield the fallback")
+
+		assert.NoError(t, digo.BindSingleton[mock.Database](&mock.FailingDB{ShouldFail: true}))
+		assert.Same(t, fallback, digo.ResolveSingletonOr[mock.Database](fallback), "A failing binding should yield the fallback")
+
+		digo.Shutdown(true)
+		bound := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](bound))
+		assert.Same(t, bound, digo.ResolveSingletonOr[mock.Database](fallback))
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("ResolveWait", func(t *testing.T) {
+		digo.Shutdown(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := digo.ResolveSingletonWait[mock.Database](ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Waiting should end with the context")
+
+		plugin := &mock.MockDB{}
+		resolved := make(chan mock.Database, 1)
+		go func() {
+			db, err := digo.ResolveSingletonWait[mock.Database](context.Background())
+			assert.NoError(t, err)
+			resolved <- db
+		}()
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, digo.BindSingleton[mock.Database](plugin))
+		select {
+		case db := <-resolved:
+			assert.Same(t, plugin, db, "The waiting resolver should get the late binding")
+		case <-time.After(time.Second):
+			t.Fatal("ResolveSingletonWait did not return after the binding was registered")
+		}
+		assert.True(t, plugin.IsConnected())
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("ResolveAs", func(t *testing.T) {
+		digo.Shutdown(true)
+		shared := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](shared))
+
+		throwaway, err := digo.ResolveAs[mock.Database](digo.ScopeTransient, digo.ScopeSingleton)
+		assert.NoError(t, err)
+		assert.NotSame(t, shared, throwaway, "A transient copy should not be the shared instance")
+		assert.True(t, throwaway.(*mock.MockDB).IsConnected(), "The copy should be booted")
+		assert.False(t, shared.IsConnected(), "The shared instance should be untouched")
+		same, err := digo.ResolveAs[mock.Database](digo.ScopeSingleton, digo.ScopeSingleton)
+		assert.NoError(t, err)
+		assert.Same(t, shared, same)
+		_, err = digo.ResolveAs[mock.Database](digo.ScopeTransient, digo.ScopeSingleton)
+		var cloneErr *digo.NotCloneableError
+		assert.ErrorAs(t, err, &cloneErr, "A booted service should not be copied")
+
+		_, err = digo.ResolveAs[mock.Database](digo.ScopeRequest, digo.ScopeSingleton)
+		var scopeErr *digo.InvalidScopeError
+		assert.ErrorAs(t, err, &scopeErr)
+
+		digo.Shutdown(true)
+		built := 0
+		assert.NoError(t, digo.ProvideSingleton(func(ctx *digo.ContainerContext) (mock.Cache, error) {
+			built++
+			return &mock.MockCache{}, nil
+		}))
+		assert.NoError(t, digo.BindTransient[mock.Database](&mock.MockDB{}, digo.NewContainerContext(context.Background())))
+		first, err := digo.ResolveAs[mock.Cache](digo.ScopeTransient, digo.ScopeSingleton)
+		assert.NoError(t, err)
+		second, err := digo.ResolveAs[mock.Cache](digo.ScopeTransient, digo.ScopeSingleton)
+		assert.NoError(t, err)
+		assert.NotSame(t, first, second)
+		assert.Equal(t, 2, built, "Providers should construct every throwaway instance")
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("AlsoConcrete", func(t *testing.T) {
+		digo.Shutdown(true)
+		db := &countingDB{}
+		assert.NoError(t, digo.BindSingletonAlsoConcrete[mock.Database](db))
+		assert.NoError(t, digo.Boot())
+
+		byInterface, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		byConcrete, err := digo.ResolveSingleton[*countingDB]()
+		assert.NoError(t, err)
+		assert.Same(t, db, byInterface)
+		assert.Same(t, db, byConcrete, "Both keys should share one instance")
+		assert.Equal(t, 1, db.boots, "OnBoot should run once for both keys")
+		assert.Empty(t, digo.PendingSingletons())
+
+		assert.NoError(t, digo.Shutdown(true))
+		assert.Equal(t, 1, db.shutdowns, "OnShutdown should run once for both keys")
+	})
+
+	t.Run("ProvideMulti", func(t *testing.T) {
+		digo.Shutdown(true)
+		db := &countingDB{}
+		calls := 0
+		provider := func(ctx *digo.ContainerContext) (digo.Lifecycle, error) {
+			calls++
+			return db, nil
+		}
+		databaseType := reflect.TypeOf((*mock.Database)(nil)).Elem()
+		lifecycleType := reflect.TypeOf((*digo.Lifecycle)(nil)).Elem()
+		assert.NoError(t, digo.ProvideMulti(provider, databaseType, lifecycleType, reflect.TypeOf(db)))
+
+		byConcrete, err := digo.ResolveSingleton[*countingDB]()
+		assert.NoError(t, err)
+		byLifecycle, err := digo.ResolveSingleton[digo.Lifecycle]()
+		assert.NoError(t, err)
+		byInterface, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, db, byConcrete)
+		assert.Same(t, db, byLifecycle)
+		assert.Same(t, db, byInterface)
+		assert.Equal(t, 1, calls, "The provider should run once for every type")
+		assert.Equal(t, 1, db.boots)
+
+		assert.NoError(t, digo.Shutdown(true))
+		assert.Equal(t, 1, db.shutdowns)
+
+		var mismatch *digo.TypeMismatchError
+		assert.ErrorAs(t, digo.ProvideMulti(provider, reflect.TypeOf("")), &mismatch)
+
+		cacheType := reflect.TypeOf((*mock.Cache)(nil)).Elem()
+		assert.NoError(t, digo.ProvideMulti(provider, databaseType, cacheType))
+		_, err = digo.ResolveSingleton[mock.Cache]()
+		var initErr *digo.InitializationError
+		assert.ErrorAs(t, err, &initErr, "An instance missing a type should fail to initialize")
+	})
+
+	t.Run("RebindAfterResolve", func(t *testing.T) {
+		digo.Shutdown(true)
+		first := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](first))
+		for i := 0; i < 2; i++ {
+			resolved, err := digo.ResolveSingleton[mock.Database]()
+			assert.NoError(t, err)
+			assert.Same(t, first, resolved)
+		}
+
+		second := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](second))
+		resolved, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, second, resolved, "Rebinding should replace the cached instance")
+	})
+
+	t.Run("ResolveMap", func(t *testing.T) {
+		digo.Shutdown(true)
+		_, err := digo.ResolveMap(func(db mock.Database) bool { return true })
+		var notFound *digo.BindingNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+
+		assert.NoError(t, digo.BindSingleton[mock.Database](&mock.MockDB{}))
+		connected, err := digo.ResolveMap(func(db mock.Database) bool {
+			return db.(*mock.MockDB).IsConnected()
+		})
+		assert.NoError(t, err)
+		assert.True(t, connected)
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("ResolveDetailed", func(t *testing.T) {
+		digo.Shutdown(true)
+		assert.NoError(t, digo.BindSingleton[mock.Database](&mock.MockDB{}))
+
+		_, info, err := digo.ResolveSingletonDetailed[mock.Database]()
+		assert.NoError(t, err)
+		assert.False(t, info.CacheHit, "The first resolution should boot the service")
+
+		_, info, err = digo.ResolveSingletonDetailed[mock.Database]()
+		assert.NoError(t, err)
+		assert.Equal(t, digo.ResolveInfo{CacheHit: true}, info)
+		assert.NoError(t, digo.Shutdown(true))
+	})
+
+	t.Run("BindOneOf", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		real := &mock.MockDB{}
+		fake := &mock.MockDB{}
+		digo.SetBaseValue("env", "test")
+		err := digo.BindOneOf[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+			if ctx.Value("env") == "production" {
+				return real, nil
+			}
+			return fake, nil
+		}, real, fake)
+		assert.NoError(t, err)
+		assert.NoError(t, digo.Boot())
+
+		instance, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, fake, instance)
+		assert.True(t, fake.IsConnected())
+		assert.False(t, real.IsConnected(), "Unselected candidates must never boot")
+		digo.Reset()
+	})
+
+	t.Run("BindOneOfUnknownSelection", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		err := digo.BindOneOf[mock.Database](func(ctx *digo.ContainerContext) (mock.Database, error) {
+			return &mock.MockDB{}, nil
+		}, &mock.MockDB{})
+		assert.NoError(t, err)
+
+		_, err = digo.ResolveSingleton[mock.Database]()
+		var noMatch *digo.NoMatchingBindingError
+		assert.ErrorAs(t, err, &noMatch)
+
+		var nilErr *digo.NilServiceError
+		assert.ErrorAs(t, digo.BindOneOf[mock.Database](nil), &nilErr)
+	})
+
+	t.Run("BindOneOfNotComparable", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		first := funcService(func() string { return "first" })
+		second := funcService(func() string { return "second" })
+		assert.NoError(t, digo.BindOneOf[funcService](func(ctx *digo.ContainerContext) (funcService, error) {
+			return second, nil
+		}, first, second))
+		instance, err := digo.ResolveSingleton[funcService]()
+		assert.NoError(t, err, "Func candidates should be matched by identity")
+		assert.Equal(t, "second", instance())
+
+		other := funcService(func() string { return "other" })
+		assert.NoError(t, digo.BindOneOf[*funcService](func(ctx *digo.ContainerContext) (*funcService, error) {
+			return &other, nil
+		}, &first))
+		_, err = digo.ResolveSingleton[*funcService]()
+		var noMatch *digo.NoMatchingBindingError
+		assert.ErrorAs(t, err, &noMatch)
+
+		assert.NoError(t, digo.BindOneOf[sliceService](func(ctx *digo.ContainerContext) (sliceService, error) {
+			return sliceService{names: []string{"replica"}}, nil
+		}, sliceService{names: []string{"primary"}}, sliceService{names: []string{"replica"}}))
+		selected, err := digo.ResolveSingleton[sliceService]()
+		assert.NoError(t, err, "Struct candidates holding slices should be matched by value")
+		assert.Equal(t, []string{"replica"}, selected.names)
+		digo.Reset()
+	})
+
+	t.Run("BootOne", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		early := &bootCounter{}
+		db := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[CountedService](early))
+		assert.NoError(t, digo.BindSingleton[mock.Database](db))
+
+		assert.NoError(t, digo.BootOne[CountedService]())
+		assert.Equal(t, 1, early.Boots())
+		assert.False(t, db.IsConnected(), "Other singletons should not boot")
+
+		assert.NoError(t, digo.BootOne[CountedService]())
+		assert.NoError(t, digo.Boot())
+		assert.Equal(t, 1, early.Boots(), "An early-booted singleton should boot only once")
+		assert.True(t, db.IsConnected())
+
+		var notFound *digo.BindingNotFoundError
+		assert.ErrorAs(t, digo.BootOne[mock.Cache](), &notFound)
+	})
+
+	t.Run("ResolveWithTimeout", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		db := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](db))
+		instance, err := digo.ResolveSingletonTimeout[mock.Database](time.Second)
+		assert.NoError(t, err)
+		assert.Same(t, db, instance)
+
+		sawDeadline := make(chan bool, 1)
+		assert.NoError(t, digo.BindSingleton[mock.Service](&blockingService{sawDeadline: sawDeadline}))
+		_, err = digo.ResolveSingletonTimeout[mock.Service](20 * time.Millisecond)
+		var timeoutErr *digo.TimeoutError
+		assert.ErrorAs(t, err, &timeoutErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, <-sawDeadline, "OnBoot should receive the deadline")
+	})
+
+	t.Run("ResolveSingletonTimeoutCircular", func(t *testing.T) {
+		digo.Shutdown(true)
+
+		outer := &timeoutOuter{}
+		assert.NoError(t, digo.BindSingleton[*timeoutOuter](outer))
+		assert.NoError(t, digo.BindSingleton[*timeoutInner](&timeoutInner{}))
+		start := time.Now()
+		_, err := digo.ResolveSingleton[*timeoutOuter]()
+		assert.NoError(t, err)
+		var circularErr *digo.CircularDependencyError
+		assert.ErrorAs(t, outer.innerErr, &circularErr, "Reaching a service booting on the caller should be circular")
+		assert.Less(t, time.Since(start), time.Second, "The bounded resolution should not wait for the caller")
+		digo.Reset()
+	})
+
+	t.Run("DefaultResolveTimeout", func(t *testing.T) {
+		digo.Shutdown(true)
+		digo.SetDefaultResolveTimeout(20 * time.Millisecond)
+		defer digo.SetDefaultResolveTimeout(0)
+
+		db := &mock.MockDB{}
+		assert.NoError(t, digo.BindSingleton[mock.Database](db))
+		instance, err := digo.ResolveSingleton[mock.Database]()
+		assert.NoError(t, err)
+		assert.Same(t, db, instance)
+
+		sawDeadline := make(chan bool, 2)
+		assert.NoError(t, digo.BindSingleton[mock.Service](&blockingService{sawDeadline: sawDeadline}))
+		_, err = digo.ResolveSingleton[mock.Service]()
+		var timeoutErr *digo.TimeoutError
+		assert.ErrorAs(t, err, &timeoutErr)
+		assert.True(t, <-sawDeadline, "OnBoot should receive the default deadline")
+
+		assert.NoError(t, digo.BindTransient[mock.Service](&blockingService{sawDeadline: sawDeadline}, digo.NewContainerContext(context.Background())))
+		_, err = digo.ResolveTransient[mock.Service]()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, <-sawDeadline)
+
+		// The bounded resolution runs in the caller's request
+		requestCtx := digo.NewContainerContext(context.Background()).WithValue("request_id", "bounded")
+		assert.NoError(t, digo.BindRequest[mock.Database](&mock.MockDB{}, digo.NewContainerContext(context.Background())))
+		assert.NoError(t, digo.BeginRequest(requestCtx))
+		requestDB, err := digo.ResolveRequest[mock.Database]()
+		assert.NoError(t, err)
+		assert.Equal(t, "bounded", requestDB.(*mock.MockDB).RequestID)
+		assert.NoError(t, digo.EndRequest("bounded"))
+
+		digo.SetDefaultResolveTimeout(0)
+		slow := &slowDB{delay: 40 * time.Millisecond}
+		assert.NoError(t, digo.BindSingleton[*slowDB](slow))
+		_, err = digo.ResolveSingleton[*slowDB]()
+		assert.NoError(t, err, "A zero default should not bound resolution")
+	})
+}
+
+// blockingService boots only when its context is cancelled
+// timeoutOuter resolves timeoutInner with a deadline while booting
+type timeoutOuter struct {
+	innerErr error
+}
+
+func (o *timeoutOuter) OnBoot(ctx *digo.ContainerContext) error {
+	_, o.innerErr = digo.ResolveSingletonTimeout[*timeoutInner](time.Second)
+	return nil
+}
+
+func (o *timeoutOuter) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// timeoutInner depends back on timeoutOuter
+type timeoutInner struct{}
+
+func (i *timeoutInner) OnBoot(ctx *digo.ContainerContext) error {
+	_, err := digo.ResolveSingleton[*timeoutOuter]()
+	return err
+}
+
+func (i *timeoutInner) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// funcService is a Lifecycle whose values are not comparable
+type funcService func() string
+
+func (f funcService) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (f funcService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+// sliceService is a struct value Lifecycle that is not comparable
+type sliceService struct {
+	names []string
+}
+
+func (s sliceService) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (s sliceService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+type blockingService struct {
+	sawDeadline chan bool
+}
+
+func (b *blockingService) OnBoot(ctx *digo.ContainerContext) error {
+	_, ok := ctx.Deadline()
+	b.sawDeadline <- ok
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b *blockingService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+func (b *blockingService) IsInitialized() bool                         { return false }
+
+// slowDB takes delay to boot
+type slowDB struct {
+	mock.MockDB
+	delay time.Duration
+}
+
+func (s *slowDB) OnBoot(ctx *digo.ContainerContext) error {
+	time.Sleep(s.delay)
+	return s.MockDB.OnBoot(ctx)
+}
+
+// countingDB counts its lifecycle calls
+type countingDB struct {
+	mock.MockDB
+	boots     int
+	shutdowns int
+}
+
+func (c *countingDB) OnBoot(ctx *digo.ContainerContext) error {
+	c.boots++
+	return c.MockDB.OnBoot(ctx)
+}
+
+func (c *countingDB) OnShutdown(ctx *digo.ContainerContext) error {
+	c.shutdowns++
+	return c.MockDB.OnShutdown(ctx)
 }
@@ -3,6 +3,8 @@ package digo_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/centraunit/digo"
@@ -91,6 +93,273 @@ func (s *ContainerTestSuite) TestDeepDependencyResolution() {
 		var initErr *digo.InitializationError
 		s.True(errors.As(err, &initErr))
 	})
+
+	s.Run("FailedChain", func() {
+		digo.Reset()
+		ctx := digo.NewContainerContext(context.Background())
+		digo.BindTransient[mock.DeepService1](&mock.DeepImpl1{}, ctx)
+		digo.BindTransient[mock.DeepService2](&mock.DeepImpl2{}, ctx)
+		digo.BindTransient[mock.DeepService3](&failingDeepService{}, ctx)
+
+		_, err := digo.ResolveTransient[mock.DeepService1]()
+		s.Error(err)
+		s.Equal([]string{
+			"transient:mock.DeepService1",
+			"transient:mock.DeepService2",
+			"transient:mock.DeepService3",
+		}, digo.FailedChain(err))
+		s.Nil(digo.FailedChain(errors.New("unrelated")))
+	})
+}
+
+// failingDeepService fails at the bottom of the deep dependency chain
+type failingDeepService struct {
+	mock.DeepImpl3
+}
+
+func (f *failingDeepService) OnBoot(ctx *digo.ContainerContext) error {
+	return errors.New("deep boot failure")
+}
+
+type InnerProbe interface {
+	digo.Lifecycle
+	Depth() int
+}
+
+type OuterProbe interface {
+	InnerProbe
+	Inner() InnerProbe
+}
+
+type innerProbe struct {
+	depth int
+}
+
+func (p *innerProbe) OnBoot(ctx *digo.ContainerContext) error {
+	p.depth = ctx.ResolutionDepth()
+	return nil
+}
+
+func (p *innerProbe) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+func (p *innerProbe) Depth() int                                  { return p.depth }
+
+type outerProbe struct {
+	innerProbe
+	inner InnerProbe
+}
+
+func (p *outerProbe) OnBoot(ctx *digo.ContainerContext) error {
+	p.depth = ctx.ResolutionDepth()
+	inner, err := digo.ResolveTransient[InnerProbe]()
+	p.inner = inner
+	return err
+}
+
+func (p *outerProbe) Inner() InnerProbe { return p.inner }
+
+func (s *ContainerTestSuite) TestResolutionDepth() {
+	ctx := digo.NewContainerContext(context.Background())
+	s.NoError(digo.BindTransient[InnerProbe](&innerProbe{}, ctx))
+	s.NoError(digo.BindSingleton[OuterProbe](&outerProbe{}))
+
+	outer, err := digo.ResolveSingleton[OuterProbe]()
+	s.NoError(err)
+	s.Equal(0, outer.Depth())
+	s.Equal(1, outer.Inner().Depth())
+
+	// Depth is relative to the chain, not accumulated across resolutions
+	inner, err := digo.ResolveTransient[InnerProbe]()
+	s.NoError(err)
+	s.Equal(0, inner.Depth())
+}
+
+// dispatcher resolves its handler on demand through the container's Resolver
+type dispatcher struct {
+	resolver digo.Resolver
+}
+
+func (d *dispatcher) OnBoot(ctx *digo.ContainerContext) error {
+	var err error
+	d.resolver, err = digo.ResolveSingleton[digo.Resolver]()
+	return err
+}
+
+func (d *dispatcher) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (d *dispatcher) Dispatch() (mock.Database, error) {
+	return digo.ResolveFrom[mock.Database](d.resolver, digo.ScopeTransient)
+}
+
+func (s *ContainerTestSuite) TestResolveResolver() {
+	ctx := digo.NewContainerContext(context.Background())
+	db := &mock.MockDB{}
+	s.NoError(digo.BindTransient[mock.Database](db, ctx))
+	s.NoError(digo.BindSingleton[*dispatcher](&dispatcher{}))
+
+	d, err := digo.ResolveSingleton[*dispatcher]()
+	s.NoError(err)
+	s.True(d.resolver.IsBound(reflect.TypeOf((*mock.Database)(nil)).Elem(), digo.ScopeTransient))
+	s.False(d.resolver.IsBound(reflect.TypeOf((*mock.Cache)(nil)).Elem(), digo.ScopeTransient))
+
+	handler, err := d.Dispatch()
+	s.NoError(err)
+	s.Same(db, handler)
+
+	_, err = digo.ResolveFrom[mock.Cache](d.resolver, digo.ScopeSingleton)
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound)
+	s.Equal(2, digo.BindingCount(), "The Resolver should not be stored as a binding")
+}
+
+func (s *ContainerTestSuite) TestResolveSingletonPtr() {
+	bound := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](bound))
+
+	var db mock.Database
+	s.NoError(digo.ResolveSingletonPtr(&db))
+	s.Same(bound, db)
+
+	var invalidErr *digo.InvalidTargetError
+	s.ErrorAs(digo.ResolveSingletonPtr("database"), &invalidErr, "A non-pointer target should be rejected")
+	s.ErrorAs(digo.ResolveSingletonPtr((*mock.Database)(nil)), &invalidErr)
+
+	var cache mock.Cache
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(digo.ResolveSingletonPtr(&cache), &notFound)
+}
+
+func (s *ContainerTestSuite) TestBootHooks() {
+	var events []string
+	digo.SetBeforeBoot(func() { events = append(events, "before") })
+	digo.SetAfterBoot(func(err error) { events = append(events, fmt.Sprintf("after: %v", err)) })
+	db := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](db))
+
+	s.NoError(digo.Boot())
+	s.NoError(digo.Boot())
+	s.Equal([]string{"before", "after: <nil>"}, events, "Hooks should bracket the boot pass once")
+
+	s.NoError(digo.Shutdown(true))
+	events = nil
+	s.NoError(digo.BindSingleton[mock.Database](&mock.FailingDB{ShouldFail: true}))
+	err := digo.BootAsync()
+	s.Error(err)
+	s.Equal([]string{"before", "after: " + err.Error()}, events, "The after hook should receive the boot error")
+}
+
+func (s *ContainerTestSuite) TestChildContainer() {
+	parentDB := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](parentDB))
+	s.NoError(digo.BindSingleton[mock.DeepService3](&mock.DeepImpl3{}))
+
+	parent := digo.GetContainer()
+	child := digo.NewChildContainer(parent)
+	defer digo.UseContainer(digo.UseContainer(child))
+
+	childDeep := &mock.DeepImpl3{}
+	s.NoError(digo.BindSingleton[mock.DeepService3](childDeep))
+	s.True(digo.IsBound[mock.Database](digo.ScopeSingleton), "Parent bindings should be visible from the child")
+
+	db, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(parentDB, db, "Missing bindings should fall back to the parent")
+	deep, err := digo.ResolveSingleton[mock.DeepService3]()
+	s.NoError(err)
+	s.Same(childDeep, deep, "Child bindings should override the parent")
+	_, err = digo.ResolveSingleton[mock.DeepService2]()
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound, "Types bound nowhere should still be reported missing")
+
+	digo.UseContainer(parent)
+	db, err = digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(parentDB, db, "The parent should own the singleton resolved through the child")
+	s.True(parentDB.IsConnected(), "The singleton should be booted by the parent")
+	deep, err = digo.ResolveSingleton[mock.DeepService3]()
+	s.NoError(err)
+	s.NotSame(childDeep, deep, "The child override should not leak into the parent")
+}
+
+// wiredService resolves its database while booting
+type wiredService struct {
+	db mock.Database
+}
+
+func (w *wiredService) OnBoot(ctx *digo.ContainerContext) error {
+	var err error
+	w.db, err = digo.ResolveSingleton[mock.Database]()
+	return err
+}
+
+func (w *wiredService) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *ContainerTestSuite) TestChildContainerParentDependencies() {
+	parentDB := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](parentDB))
+	s.NoError(digo.BindSingleton[*wiredService](&wiredService{}))
+
+	parent := digo.GetContainer()
+	child := digo.NewChildContainer(parent)
+	defer digo.UseContainer(digo.UseContainer(child))
+	childDB := &mock.MockDB{}
+	s.NoError(digo.BindSingleton[mock.Database](childDB))
+
+	wired, err := digo.ResolveSingleton[*wiredService]()
+	s.NoError(err)
+	s.Same(parentDB, wired.db, "A parent singleton booted through a child should be wired from the parent")
+	s.False(childDB.IsConnected())
+
+	db, err := digo.ResolveSingleton[mock.Database]()
+	s.NoError(err)
+	s.Same(childDB, db, "The child should keep its override after the parent boot")
+}
+
+// settings is a Lifecycle implemented on a struct value
+type settings struct {
+	Env string
+}
+
+func (c settings) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (c settings) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+
+func (s *ContainerTestSuite) TestConcreteTypeBinding() {
+	s.NoError(digo.BindSingleton(settings{Env: "prod"}), "Struct values should bind under their own type")
+	resolved, err := digo.ResolveSingleton[settings]()
+	s.NoError(err)
+	s.Equal(settings{Env: "prod"}, resolved)
+
+	db := &mock.MockDB{}
+	s.NoError(digo.BindSingleton(db))
+	resolvedDB, err := digo.ResolveSingleton[*mock.MockDB]()
+	s.NoError(err)
+	s.Same(db, resolvedDB)
+	s.True(db.IsConnected())
+	_, err = digo.ResolveSingleton[mock.Database]()
+	var notFound *digo.BindingNotFoundError
+	s.ErrorAs(err, &notFound, "A concrete binding should not satisfy its interfaces")
+
+	var nilDB *mock.MockDB
+	var nilErr *digo.NilServiceError
+	s.ErrorAs(digo.BindSingleton(nilDB), &nilErr)
+}
+
+// halfDB implements Lifecycle but not the rest of mock.Database
+type halfDB struct{}
+
+func (h *halfDB) OnBoot(ctx *digo.ContainerContext) error     { return nil }
+func (h *halfDB) OnShutdown(ctx *digo.ContainerContext) error { return nil }
+func (h *halfDB) Connect()                                    {}
+
+var _ = digo.AssertImplements[mock.Database, mock.MockDB]()
+
+func (s *ContainerTestSuite) TestAssertImplements() {
+	s.True(digo.AssertImplements[mock.Database, mock.MockDB]())
+	s.PanicsWithValue("digo: *digo_test.halfDB does not implement mock.Database (wrong type for method Connect)", func() {
+		digo.AssertImplements[mock.Database, halfDB]()
+	})
+	s.PanicsWithValue("digo: *mock.MockCache does not implement mock.Database (missing method Connect)", func() {
+		digo.AssertImplements[mock.Database, mock.MockCache]()
+	})
 }
 
 func TestContainerSuite(t *testing.T) {
@@ -0,0 +1,101 @@
+package digo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// sessionIDOf returns the session_id carried by ctx, or "" if there is none.
+func sessionIDOf(ctx *ContainerContext) string {
+	if ctx == nil {
+		return ""
+	}
+	sessionID := ctx.Value("session_id")
+	if sessionID == nil {
+		return ""
+	}
+	if str, ok := sessionID.(string); ok {
+		return str
+	}
+	return fmt.Sprint(sessionID)
+}
+
+// BindSession registers a service with session scope for the session identified
+// by the session_id in ctx. A session instance is booted on its first
+// resolution and lives across requests until EndSession; Boot and Shutdown(false)
+// leave it alone, while Shutdown(true) shuts it down with the singletons.
+// Returns MissingContextValueError if session_id is not in the binding context.
+// Returns InvalidContextValueError if session_id is not a string.
+// Returns NilServiceError if the service is nil.
+func BindSession[T Lifecycle](service T, ctx *ContainerContext) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, ScopeSession, ctx)
+	if err != nil {
+		return err
+	}
+	key := makeBindingKey(ScopeSession, serviceType)
+	if key.request = sessionIDOf(binding.ctx); key.request == "" {
+		return &MissingContextValueError{Key: "session_id"}
+	}
+	instance.register(key, binding)
+	return nil
+}
+
+// ResolveSession resolves the session-scoped T of the session identified by the
+// session_id in ctx. The same instance is returned until EndSession.
+// Returns MissingContextValueError if session_id is not in ctx.
+// Returns InvalidContextValueError if session_id is not a string.
+// Returns BindingNotFoundError if the session has no binding for T.
+// Returns InitializationError if service fails to initialize.
+func ResolveSession[T Lifecycle](ctx *ContainerContext) (T, error) {
+	if ctx != nil {
+		if err := checkStringValue(ctx, "session_id"); err != nil {
+			var zero T
+			return zero, GetContainer().wrapError(err)
+		}
+	}
+	sessionID := sessionIDOf(ctx)
+	if sessionID == "" {
+		var zero T
+		return zero, GetContainer().wrapError(&MissingContextValueError{Key: "session_id"})
+	}
+	key := makeBindingKey(ScopeSession, reflect.TypeOf((*T)(nil)).Elem())
+	key.request = sessionID
+	// A session instance is a singleton within its session
+	return wrapResult(resolveSingleton[T](key))
+}
+
+// EndSession shuts down and removes every session-scoped instance of sessionID,
+// in reverse registration order.
+// Returns the first ShutdownError encountered; the instances are removed regardless.
+func EndSession(sessionID string) error {
+	instance := GetContainer()
+	instance.mu.Lock()
+	var ended []bindingDefinition
+	for _, binding := range instance.snapshot() {
+		if binding.scope != ScopeSession || binding.key.request != sessionID {
+			continue
+		}
+		instance.bindings.Delete(binding.key)
+		instance.initLocks.Delete(binding.key)
+		ended = append(ended, binding)
+	}
+	instance.mu.Unlock()
+
+	sort.Slice(ended, func(i, j int) bool { return ended[i].id > ended[j].id })
+	var firstErr error
+	for _, binding := range ended {
+		if !binding.initialized {
+			continue
+		}
+		if err := instance.shutdownBinding(binding.key, binding); err != nil && firstErr == nil {
+			firstErr = &ShutdownError{Type: reflect.TypeOf(binding.concrete).String(), Err: err}
+		}
+	}
+	return firstErr
+}
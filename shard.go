@@ -0,0 +1,120 @@
+package digo
+
+import (
+	"reflect"
+	"sync"
+)
+
+// defaultShards is the number of shards of the default binding store.
+const defaultShards = 32
+
+// bindingShard is one independently locked part of a shardedStore.
+type bindingShard struct {
+	mu       sync.RWMutex
+	bindings map[bindingKey]bindingDefinition
+}
+
+// shardedStore is the default binding store. It spreads bindings over shards by
+// the identity of their type, each with its own lock, so it is safe for
+// concurrent use and the container reads it without holding its own lock:
+// resolutions do not wait for binds of unrelated types. Each binding is read
+// and written atomically, but a Range may observe a concurrent Set or Delete
+// in a shard it has not visited yet.
+type shardedStore struct {
+	shards []bindingShard
+}
+
+func newShardedStore(n int) *shardedStore {
+	if n < 1 {
+		n = 1
+	}
+	store := &shardedStore{shards: make([]bindingShard, n)}
+	for i := range store.shards {
+		store.shards[i].bindings = make(map[bindingKey]bindingDefinition)
+	}
+	return store
+}
+
+// shard returns the shard of key. Keys of the same type share a shard.
+func (s *shardedStore) shard(key bindingKey) *bindingShard {
+	var h uint64
+	if key.typ != nil {
+		// Fibonacci hashing spreads the aligned type pointers over the shards
+		h = uint64(reflect.ValueOf(key.typ).Pointer()) * 0x9E3779B97F4A7C15
+	}
+	return &s.shards[(h>>32)%uint64(len(s.shards))]
+}
+
+func (s *shardedStore) Get(key bindingKey) (bindingDefinition, bool) {
+	shard := s.shard(key)
+	shard.mu.RLock()
+	binding, ok := shard.bindings[key]
+	shard.mu.RUnlock()
+	return binding, ok
+}
+
+func (s *shardedStore) Set(key bindingKey, binding bindingDefinition) {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	shard.bindings[key] = binding
+	shard.mu.Unlock()
+}
+
+func (s *shardedStore) Delete(key bindingKey) {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	delete(shard.bindings, key)
+	shard.mu.Unlock()
+}
+
+// Range visits a copy of each shard, so fn may update the store.
+func (s *shardedStore) Range(fn func(key bindingKey, binding bindingDefinition) bool) {
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		keys := make([]bindingKey, 0, len(shard.bindings))
+		bindings := make([]bindingDefinition, 0, len(shard.bindings))
+		for key, binding := range shard.bindings {
+			keys = append(keys, key)
+			bindings = append(bindings, binding)
+		}
+		shard.mu.RUnlock()
+		for i, key := range keys {
+			if !fn(key, bindings[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (s *shardedStore) Len() int {
+	n := 0
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		n += len(shard.bindings)
+		shard.mu.RUnlock()
+	}
+	return n
+}
+
+// WithShards sets the number of shards of the default binding store. It has no
+// effect on a store installed with WithBindingStore.
+func WithShards(n int) ContainerOption {
+	return func(c *container) {
+		if _, ok := c.bindings.(*shardedStore); ok {
+			c.bindings = newShardedStore(n)
+		}
+	}
+}
+
+// getBinding returns the binding under key, taking the container lock only if
+// the store is not safe for concurrent use.
+func (c *container) getBinding(key bindingKey) (bindingDefinition, bool) {
+	if _, ok := c.bindings.(*shardedStore); ok {
+		return c.bindings.Get(key)
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bindings.Get(key)
+}
@@ -0,0 +1,84 @@
+package digo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// slowWatchMinInterval bounds how often the slow resolution watcher polls.
+const slowWatchMinInterval = time.Millisecond
+
+// resolveStart records when a key entered a resolution chain.
+type resolveStart struct {
+	at       time.Time
+	reported bool
+}
+
+// SetSlowResolveThreshold starts a background watcher that logs every key still
+// being resolved after d, together with the resolution chain it belongs to, to
+// diagnose resolutions that appear stuck under contention. Each slow key is
+// logged once per resolution. Passing 0 stops the watcher. Like the logger, the
+// threshold survives Reset.
+func SetSlowResolveThreshold(d time.Duration) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	if instance.slowStop != nil {
+		close(instance.slowStop)
+		instance.slowStop = nil
+	}
+	instance.slowThreshold.Store(int64(d))
+	if d > 0 {
+		stop := make(chan struct{})
+		instance.slowStop = stop
+		go instance.watchSlowResolutions(d, stop)
+	}
+}
+
+// watchSlowResolutions polls the resolution states until stop is closed.
+func (c *container) watchSlowResolutions(threshold time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(max(threshold/2, slowWatchMinInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.reportSlowResolutions(threshold)
+		}
+	}
+}
+
+// reportSlowResolutions logs the keys that entered a chain more than threshold ago.
+func (c *container) reportSlowResolutions(threshold time.Duration) {
+	now := time.Now()
+	var reports []string
+	c.resolutionMu.RLock()
+	c.resolutionState.Range(func(_, value interface{}) bool {
+		state := value.(*resolutionState)
+		state.mu.Lock()
+		for key, start := range state.started {
+			elapsed := now.Sub(start.at)
+			if start.reported || elapsed < threshold {
+				continue
+			}
+			start.reported = true
+			state.started[key] = start
+			chain := make([]string, len(state.keyCache))
+			for i, k := range state.keyCache {
+				chain[i] = k.String()
+			}
+			reports = append(reports, fmt.Sprintf("digo: resolving %s for %v (chain: %s)",
+				key, elapsed.Round(time.Millisecond), strings.Join(chain, " -> ")))
+		}
+		state.mu.Unlock()
+		return true
+	})
+	c.resolutionMu.RUnlock()
+
+	for _, report := range reports {
+		c.logf("%s", report)
+	}
+}
@@ -0,0 +1,93 @@
+package digo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ResolutionStats holds the container's operation counters, accumulated since
+// the container was created or ResetStats was last called.
+type ResolutionStats struct {
+	// Resolutions counts successful resolutions, including cached ones.
+	Resolutions uint64
+	// Boots and Shutdowns count successful OnBoot and OnShutdown calls.
+	Boots     uint64
+	Shutdowns uint64
+	// Errors counts failed resolutions, boots and shutdowns.
+	Errors uint64
+	// BootTime is the total time spent in successful and failed OnBoot calls.
+	BootTime time.Duration
+}
+
+// statCounters accumulates ResolutionStats without locking.
+type statCounters struct {
+	resolutions atomic.Uint64
+	boots       atomic.Uint64
+	shutdowns   atomic.Uint64
+	errors      atomic.Uint64
+	bootTime    atomic.Int64
+}
+
+// Stats returns a snapshot of the container's operation counters. Counters
+// survive Reset, so long-running processes can sample rates per interval by
+// pairing Stats with ResetStats.
+func Stats() ResolutionStats {
+	counters := &GetContainer().stats
+	return ResolutionStats{
+		Resolutions: counters.resolutions.Load(),
+		Boots:       counters.boots.Load(),
+		Shutdowns:   counters.shutdowns.Load(),
+		Errors:      counters.errors.Load(),
+		BootTime:    time.Duration(counters.bootTime.Load()),
+	}
+}
+
+// ResetStats zeros the counters reported by Stats without touching bindings.
+func ResetStats() {
+	counters := &GetContainer().stats
+	counters.resolutions.Store(0)
+	counters.boots.Store(0)
+	counters.shutdowns.Store(0)
+	counters.errors.Store(0)
+	counters.bootTime.Store(0)
+}
+
+// count adds an event to the counters.
+func (s *statCounters) count(kind EventKind, err error) {
+	if err != nil {
+		s.errors.Add(1)
+		return
+	}
+	switch kind {
+	case EventResolve:
+		s.resolutions.Add(1)
+	case EventBoot:
+		s.boots.Add(1)
+	case EventShutdown:
+		s.shutdowns.Add(1)
+	}
+}
+
+// BindingCount returns the number of bindings currently registered.
+// Request bindings count once per request.
+func BindingCount() int {
+	instance := GetContainer()
+	instance.mu.RLock()
+	defer instance.mu.RUnlock()
+	return instance.bindings.Len()
+}
+
+// ResolutionStateCount returns the number of goroutines that currently hold a
+// resolution state. Outside of an in-flight resolution it should be zero; a
+// growing value points to states that were never released.
+func ResolutionStateCount() int {
+	instance := GetContainer()
+	instance.resolutionMu.RLock()
+	defer instance.resolutionMu.RUnlock()
+	count := 0
+	instance.resolutionState.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
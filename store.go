@@ -0,0 +1,143 @@
+package digo
+
+// BindingKey identifies a binding held by a BindingStore. Keys are comparable,
+// so stores can use them as map keys, and describe themselves through String.
+type BindingKey struct {
+	key bindingKey
+}
+
+func (k BindingKey) String() string {
+	return k.key.String()
+}
+
+// StoredBinding is a binding held by a BindingStore. It is opaque: stores only
+// keep and return it.
+type StoredBinding struct {
+	binding bindingDefinition
+}
+
+// Key returns the key the binding is registered under.
+func (b StoredBinding) Key() BindingKey {
+	return BindingKey{key: b.binding.key}
+}
+
+// BindingStore holds the bindings of a container. The container guards every
+// call to a store installed with WithBindingStore with its own lock: Get, Range
+// and Len may run concurrently with each other but never with Set or Delete,
+// and the store is never modified during Range.
+type BindingStore interface {
+	Get(key BindingKey) (StoredBinding, bool)
+	Set(key BindingKey, binding StoredBinding)
+	Delete(key BindingKey)
+	Range(fn func(key BindingKey, binding StoredBinding) bool)
+	Len() int
+}
+
+// bindingStore is the store the container uses internally. The built-in stores
+// implement it directly; a BindingStore is adapted by customStore.
+type bindingStore interface {
+	Get(key bindingKey) (bindingDefinition, bool)
+	Set(key bindingKey, binding bindingDefinition)
+	Delete(key bindingKey)
+	Range(fn func(key bindingKey, binding bindingDefinition) bool)
+	Len() int
+}
+
+// customStore adapts a BindingStore installed with WithBindingStore.
+type customStore struct {
+	store BindingStore
+}
+
+func (s customStore) Get(key bindingKey) (bindingDefinition, bool) {
+	stored, ok := s.store.Get(BindingKey{key: key})
+	return stored.binding, ok
+}
+
+func (s customStore) Set(key bindingKey, binding bindingDefinition) {
+	s.store.Set(BindingKey{key: key}, StoredBinding{binding: binding})
+}
+
+func (s customStore) Delete(key bindingKey) {
+	s.store.Delete(BindingKey{key: key})
+}
+
+func (s customStore) Range(fn func(key bindingKey, binding bindingDefinition) bool) {
+	s.store.Range(func(key BindingKey, stored StoredBinding) bool {
+		return fn(key.key, stored.binding)
+	})
+}
+
+func (s customStore) Len() int {
+	return s.store.Len()
+}
+
+// mapStore is a plain in-memory store, relying on the container's lock.
+type mapStore map[bindingKey]bindingDefinition
+
+func (m mapStore) Get(key bindingKey) (bindingDefinition, bool) {
+	binding, ok := m[key]
+	return binding, ok
+}
+
+func (m mapStore) Set(key bindingKey, binding bindingDefinition) {
+	m[key] = binding
+}
+
+func (m mapStore) Delete(key bindingKey) {
+	delete(m, key)
+}
+
+func (m mapStore) Range(fn func(key bindingKey, binding bindingDefinition) bool) {
+	for key, binding := range m {
+		if !fn(key, binding) {
+			return
+		}
+	}
+}
+
+func (m mapStore) Len() int {
+	return len(m)
+}
+
+// ContainerOption configures a container built with NewContainerWithOptions.
+type ContainerOption func(*container)
+
+// WithBindingStore makes the container keep its bindings in store instead of the
+// default sharded in-memory store, for instance to add instrumentation or custom
+// locking. The store should be empty.
+func WithBindingStore(store BindingStore) ContainerOption {
+	return func(c *container) {
+		if store != nil {
+			c.bindings = customStore{store: store}
+		}
+	}
+}
+
+// NewContainerWithOptions creates a standalone container, typically composed
+// into the global container with Import.
+func NewContainerWithOptions(opts ...ContainerOption) *container {
+	c := newContainer()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// snapshot returns every stored binding, so callers can update the store while
+// walking the result. Callers must hold c.mu.
+func (c *container) snapshot() []bindingDefinition {
+	bindings := make([]bindingDefinition, 0, c.bindings.Len())
+	c.bindings.Range(func(_ bindingKey, binding bindingDefinition) bool {
+		bindings = append(bindings, binding)
+		return true
+	})
+	return bindings
+}
+
+// clearBindings removes every stored binding. Callers must hold c.mu.
+func (c *container) clearBindings() {
+	for _, binding := range c.snapshot() {
+		c.bindings.Delete(binding.key)
+		binding.stopReleasing()
+	}
+}
@@ -0,0 +1,157 @@
+package digo
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// countingStore is a map-backed BindingStore that counts writes
+type countingStore struct {
+	bindings map[BindingKey]StoredBinding
+	sets     int
+	deletes  int
+}
+
+func newCountingStore() *countingStore {
+	return &countingStore{bindings: make(map[BindingKey]StoredBinding)}
+}
+
+func (s *countingStore) Get(key BindingKey) (StoredBinding, bool) {
+	binding, ok := s.bindings[key]
+	return binding, ok
+}
+
+func (s *countingStore) Set(key BindingKey, binding StoredBinding) {
+	s.sets++
+	s.bindings[key] = binding
+}
+
+func (s *countingStore) Delete(key BindingKey) {
+	s.deletes++
+	delete(s.bindings, key)
+}
+
+func (s *countingStore) Range(fn func(key BindingKey, binding StoredBinding) bool) {
+	for key, binding := range s.bindings {
+		if !fn(key, binding) {
+			return
+		}
+	}
+}
+
+func (s *countingStore) Len() int {
+	return len(s.bindings)
+}
+
+type BindingStoreTestSuite struct {
+	suite.Suite
+}
+
+func (s *BindingStoreTestSuite) TestCustomStore() {
+	store := newCountingStore()
+	c := NewContainerWithOptions(WithBindingStore(store))
+	serviceType := reflect.TypeOf((*Lifecycle)(nil)).Elem()
+
+	s.NoError(c.bind(&moduleService{}, serviceType, ScopeSingleton, nil))
+	s.NoError(c.bind(&moduleService{}, serviceType, ScopeTransient, nil))
+	s.Equal(2, store.sets)
+	s.Equal(2, store.Len())
+
+	key := BindingKey{key: makeBindingKey(ScopeSingleton, serviceType)}
+	binding, ok := store.Get(key)
+	s.True(ok)
+	s.Equal(key, binding.Key())
+	s.Equal(key.key.String(), key.String())
+
+	c.mu.Lock()
+	c.clearBindings()
+	c.mu.Unlock()
+	s.Equal(2, store.deletes)
+	s.Equal(0, store.Len())
+}
+
+func (s *BindingStoreTestSuite) TestDefaultStore() {
+	c := NewContainerWithOptions(WithBindingStore(nil))
+	s.IsType(&shardedStore{}, c.bindings)
+
+	c = NewContainerWithOptions(WithShards(4))
+	s.Len(c.bindings.(*shardedStore).shards, 4)
+	c = NewContainerWithOptions(WithBindingStore(newCountingStore()), WithShards(4))
+	s.IsType(customStore{}, c.bindings, "WithShards should not replace a custom store")
+}
+
+func (s *BindingStoreTestSuite) TestShardedStore() {
+	store := newShardedStore(8)
+	types := []reflect.Type{reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf(1.0), reflect.TypeOf(false)}
+	for i, typ := range types {
+		key := makeBindingKey(ScopeSingleton, typ)
+		store.Set(key, bindingDefinition{key: key, id: uint64(i + 1)})
+	}
+	s.Equal(len(types), store.Len())
+
+	binding, ok := store.Get(makeBindingKey(ScopeSingleton, reflect.TypeOf("")))
+	s.True(ok)
+	s.Equal(uint64(2), binding.id)
+
+	// Range tolerates modifications of the store
+	store.Range(func(key bindingKey, _ bindingDefinition) bool {
+		store.Delete(key)
+		return true
+	})
+	s.Equal(0, store.Len())
+}
+
+// BenchmarkBindingStores compares the default sharded store with a plain map
+// behind a single lock, as the container uses for custom stores, on mostly
+// reads of unrelated types with concurrent writes.
+func BenchmarkBindingStores(b *testing.B) {
+	types := []reflect.Type{
+		reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf(1.0), reflect.TypeOf(false),
+		reflect.TypeOf(int8(0)), reflect.TypeOf(int16(0)), reflect.TypeOf(int32(0)), reflect.TypeOf(int64(0)),
+		reflect.TypeOf(uint8(0)), reflect.TypeOf(uint16(0)), reflect.TypeOf(uint32(0)), reflect.TypeOf(uint64(0)),
+	}
+	run := func(b *testing.B, get func(bindingKey), set func(bindingKey)) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				key := makeBindingKey(ScopeSingleton, types[i%len(types)])
+				if i%10 == 0 {
+					set(key)
+				} else {
+					get(key)
+				}
+				i++
+			}
+		})
+	}
+
+	b.Run("Locked", func(b *testing.B) {
+		var mu sync.RWMutex
+		store := make(mapStore)
+		run(b, func(key bindingKey) {
+			mu.RLock()
+			store.Get(key)
+			mu.RUnlock()
+		}, func(key bindingKey) {
+			mu.Lock()
+			store.Set(key, bindingDefinition{key: key})
+			mu.Unlock()
+		})
+	})
+
+	b.Run("Sharded", func(b *testing.B) {
+		store := newShardedStore(defaultShards)
+		run(b, func(key bindingKey) {
+			store.Get(key)
+		}, func(key bindingKey) {
+			store.Set(key, bindingDefinition{key: key})
+		})
+	})
+}
+
+func TestBindingStoreSuite(t *testing.T) {
+	suite.Run(t, new(BindingStoreTestSuite))
+}
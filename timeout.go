@@ -0,0 +1,125 @@
+package digo
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// ResolveSingletonTimeout resolves a singleton, bounding the resolution
+// (including OnBoot) by d. OnBoot receives a context carrying the deadline so
+// cooperative services can bail out early. A resolution that times out is
+// abandoned rather than stopped: it runs on until OnBoot returns, so OnBoot
+// should honour its context's deadline.
+// Returns TimeoutError wrapping context.DeadlineExceeded if d elapses first.
+// Returns CircularDependencyError if called from an OnBoot whose resolution
+// T depends on.
+func ResolveSingletonTimeout[T Lifecycle](d time.Duration) (T, error) {
+	instance := GetContainer()
+	key := makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem())
+	return wrapResult(resolveWithin(instance, key, d, func() (T, error) {
+		return resolveKey[T](key)
+	}))
+}
+
+// SetDefaultResolveTimeout bounds every top-level resolution made through the
+// Resolve functions, including the OnBoot calls it triggers, by d, as
+// ResolveSingletonTimeout does. A resolution that overruns it returns
+// TimeoutError. Passing 0 disables the default. Like the logger, the default
+// survives Reset.
+func SetDefaultResolveTimeout(d time.Duration) {
+	GetContainer().resolveTimeout.Store(int64(d))
+}
+
+// boundedByDefault runs resolve bounded by the default resolve timeout, unless
+// none is set or the calling goroutine is already inside a resolution.
+func boundedByDefault[R any](instance *container, key bindingKey, resolve func() (R, error)) (R, error) {
+	d := time.Duration(instance.resolveTimeout.Load())
+	if d <= 0 || instance.resolvingOnCaller() {
+		return resolve()
+	}
+	return resolveWithin(instance, key, d, resolve)
+}
+
+// callerChain returns the keys the calling goroutine is resolving.
+func (c *container) callerChain() []bindingKey {
+	state, ok := c.resolutionState.Load(c.getGoroutineID())
+	if !ok {
+		return nil
+	}
+	rs := state.(*resolutionState)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	chain := make([]bindingKey, 0, len(rs.chain))
+	for key := range rs.chain {
+		chain = append(chain, key)
+	}
+	return chain
+}
+
+// resolvingOnCaller reports whether the calling goroutine is inside a
+// resolution chain or already bounded by a deadline.
+func (c *container) resolvingOnCaller() bool {
+	state, ok := c.resolutionState.Load(c.getGoroutineID())
+	if !ok {
+		return false
+	}
+	rs := state.(*resolutionState)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return len(rs.chain) > 0 || rs.deadline != nil
+}
+
+// resolveWithin runs resolve on a separate goroutine so the caller can give up
+// once d elapses. An abandoned resolution keeps running to completion; its only
+// signal is the deadline carried by the OnBoot context. The goroutine joins the
+// caller's request, if it has one, and inherits the caller's resolution chain,
+// so reaching a service the caller is booting is reported as circular instead
+// of waiting for the caller until the deadline.
+func resolveWithin[R any](instance *container, key bindingKey, d time.Duration, resolve func() (R, error)) (R, error) {
+	var zero R
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	inherited := instance.callerChain()
+
+	type result struct {
+		value R
+		err   error
+	}
+	done := make(chan result, 1)
+	request := instance.activeRequest()
+	run := func() {
+		state := instance.pinState()
+		state.mu.Lock()
+		state.deadline = ctx
+		for _, key := range inherited {
+			state.chain[key] = true
+		}
+		state.mu.Unlock()
+		defer func() {
+			state.mu.Lock()
+			for _, key := range inherited {
+				delete(state.chain, key)
+			}
+			state.mu.Unlock()
+			instance.unpinState(state)
+		}()
+
+		value, err := resolve()
+		done <- result{value: value, err: err}
+	}
+	go func() {
+		if request != nil {
+			WithRequestScope(request, run)
+			return
+		}
+		run()
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, &TimeoutError{Type: key.typ.String(), Err: ctx.Err()}
+	}
+}
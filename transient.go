@@ -0,0 +1,299 @@
+package digo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindTransientFresh registers a service with transient scope whose resolutions
+// each receive a new instance instead of shutting down and re-booting a shared one.
+// The service acts as a prototype: every resolve boots a shallow copy of it.
+// The container does not track fresh instances, so callers own their shutdown.
+// Returns NilServiceError if the service is nil.
+// Returns NotCloneableError if the service is not a pointer to a struct.
+func BindTransientFresh[T Lifecycle](service T, ctx *ContainerContext, predicate ...ContextPredicate) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newBinding(service, serviceType, ScopeTransient, ctx)
+	if err != nil {
+		return err
+	}
+	if !isCloneable(service) {
+		return &NotCloneableError{Type: reflect.TypeOf(service).String()}
+	}
+	if len(predicate) > 0 {
+		binding.predicate = predicate[0]
+	}
+	binding.fresh = true
+
+	instance.register(makeBindingKey(ScopeTransient, serviceType), binding)
+	return nil
+}
+
+// TransientStrategy selects how transient bindings without a factory produce
+// the instance of each resolution.
+type TransientStrategy int
+
+const (
+	// ReuseAndReinit shuts down the bound instance and boots it again on every
+	// resolution. It allocates nothing, but every caller shares the one
+	// instance, so state set by one resolution is visible to the others.
+	ReuseAndReinit TransientStrategy = iota
+	// NewViaReflect boots a newly allocated zero value of the bound concrete
+	// type on every resolution, so OnBoot must fully initialize it; fields set
+	// on the bound instance are not carried over, unlike BindTransientFresh,
+	// which copies them. The container does not track the instances, so
+	// callers own their shutdown. Services that are neither structs nor
+	// pointers to structs fail with NotCloneableError.
+	NewViaReflect
+	// NewViaFactory constructs every instance with the factory registered by
+	// ProvideTransient, the only way to build instances with their own
+	// dependencies and configuration. Bindings without a factory fail with
+	// FactoryRequiredError.
+	NewViaFactory
+)
+
+// SetTransientStrategy sets how transient bindings without a factory are
+// resolved. Bindings registered with ProvideTransient always call their
+// factory, and those registered with BindTransientFresh keep copying their
+// prototype unless the strategy is NewViaFactory.
+// Reset restores ReuseAndReinit.
+func SetTransientStrategy(strategy TransientStrategy) {
+	instance := GetContainer()
+	instance.mu.Lock()
+	instance.transientPolicy = strategy
+	instance.mu.Unlock()
+}
+
+// allocateTransient boots a newly allocated instance of the concrete type of
+// binding, or of the service its predicate selects, as NewViaReflect does.
+func allocateTransient[T Lifecycle](c *container, key bindingKey, binding bindingDefinition, bootCtx *ContainerContext) (T, error) {
+	var zero T
+	service := binding.concrete
+	if binding.hasCondition() {
+		result, err := c.evaluateInRequest(key, binding)
+		if err != nil {
+			return zero, err
+		}
+		service = result
+	}
+	allocated, ok := allocateService(service)
+	if !ok {
+		return zero, &NotCloneableError{Type: reflect.TypeOf(service).String()}
+	}
+	typed, ok := allocated.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Expected: key.typ.String(), Got: reflect.TypeOf(allocated).String()}
+	}
+	if err := c.bootService(key, typed, c.bootContext(key, bootCtx)); err != nil {
+		return zero, c.initializationError(key.typ, err)
+	}
+	return typed, nil
+}
+
+// allocateService returns a zero value of the concrete type of service, which
+// must be a struct or a pointer to a struct.
+func allocateService(service Lifecycle) (Lifecycle, bool) {
+	t := reflect.TypeOf(service)
+	switch {
+	case t.Kind() == reflect.Struct:
+		return reflect.Zero(t).Interface().(Lifecycle), true
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		return reflect.New(t.Elem()).Interface().(Lifecycle), true
+	}
+	return nil, false
+}
+
+// transientFactory constructs a transient service from per-call arguments.
+type transientFactory func(ctx *ContainerContext, args []any) (Lifecycle, error)
+
+// ProvideTransient registers a transient service constructed by factory on every
+// resolution. ResolveTransientWith passes its arguments to the factory, while
+// ResolveTransient calls it without arguments. As with BindTransientFresh, the
+// container does not track the instances, so callers own their shutdown.
+// A factory error surfaces as InitializationError.
+func ProvideTransient[T Lifecycle](factory func(ctx *ContainerContext, args ...any) (T, error), ctx *ContainerContext) error {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	if factory == nil {
+		return &NilServiceError{Type: serviceType.String()}
+	}
+
+	instance := GetContainer()
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	binding, err := instance.newProvidedBinding(serviceType, ScopeTransient, ctx)
+	if err != nil {
+		return err
+	}
+	binding.factory = func(ctx *ContainerContext, args []any) (Lifecycle, error) {
+		return factory(ctx, args...)
+	}
+	instance.register(makeBindingKey(ScopeTransient, serviceType), binding)
+	return nil
+}
+
+// ResolveTransientWith resolves a transient service registered with
+// ProvideTransient, passing args to its factory.
+// Returns ArgumentsNotSupportedError if the binding has no factory.
+// Returns BindingNotFoundError if service is not registered.
+func ResolveTransientWith[T Lifecycle](args ...any) (_ T, err error) {
+	instance := GetContainer()
+	key := makeBindingKey(ScopeTransient, reflect.TypeOf((*T)(nil)).Elem())
+	defer func() { err = instance.wrapError(err) }()
+	defer func() { instance.recordEvent(EventResolve, key, err) }()
+	var zero T
+
+	if typed, ok, err := intercept[T](instance, key); ok {
+		return typed, err
+	}
+
+	instance.mu.RLock()
+	binding, ok := instance.bindings.Get(key)
+	instance.mu.RUnlock()
+	if !ok {
+		return zero, instance.missingBinding(key)
+	}
+	if binding.factory == nil {
+		return zero, &ArgumentsNotSupportedError{Type: key.typ.String()}
+	}
+
+	if err := instance.startResolving(key); err != nil {
+		return zero, err
+	}
+	defer instance.finishResolving(key)
+	return constructTransient[T](instance, key, binding, binding.ctx, args)
+}
+
+// ResolveAs resolves T from its binding in boundScope with the lifecycle of
+// requestedScope. When the scopes are equal it is a plain resolution in that
+// scope. Requesting ScopeTransient returns a throwaway instance of a normally
+// shared service, booted but never stored or shut down by the container, so
+// the caller owns it. The instance is only truly fresh if the binding can
+// construct one: factory and provider bindings call their constructor, others
+// get a shallow copy of the bound service that shares its pointers, maps and
+// connections. Only a service that has not booted yet is copied, since a booted
+// one is in use and copying it would race with its owners.
+// Returns NotCloneableError if the bound service can be neither constructed nor
+// copied, including when it has already booted.
+// Returns InvalidScopeError for any other requested scope.
+// Returns BindingNotFoundError if T is not bound with boundScope.
+func ResolveAs[T Lifecycle](requestedScope, boundScope Scope) (T, error) {
+	key := makeBindingKey(boundScope, reflect.TypeOf((*T)(nil)).Elem())
+	if requestedScope == boundScope {
+		return wrapResult(resolveKey[T](key))
+	}
+	instance := GetContainer()
+	if requestedScope != ScopeTransient {
+		var zero T
+		return zero, instance.wrapError(&InvalidScopeError{Type: key.typ.String(), Scope: string(requestedScope)})
+	}
+	return wrapResult(resolveThrowaway[T](instance, key))
+}
+
+// resolveThrowaway boots a new instance of the binding of key without storing it.
+func resolveThrowaway[T Lifecycle](instance *container, key bindingKey) (_ T, err error) {
+	defer func() { instance.recordEvent(EventResolve, key, err) }()
+	var zero T
+	if key.scope == ScopeRequest || key.scope == ScopeSession {
+		key = instance.currentRequestKey(key)
+	}
+	binding, ok := instance.getBinding(key)
+	if !ok {
+		return zero, instance.missingBinding(key)
+	}
+
+	if err := instance.startResolving(key); err != nil {
+		return zero, err
+	}
+	defer instance.finishResolving(key)
+
+	if binding.factory != nil {
+		return constructTransient[T](instance, key, binding, binding.ctx, nil)
+	}
+	var service Lifecycle
+	if binding.provider != nil {
+		// Call the provider again rather than copying the instance it built
+		binding.setConcrete(nil)
+		if binding, err = binding.materialize(); err != nil {
+			return zero, instance.initializationError(key.typ, err)
+		}
+		service = binding.concrete
+	} else if binding, service, err = copyUnbooted(instance, key); err != nil {
+		return zero, err
+	}
+
+	typed, ok := service.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Expected: key.typ.String(), Got: reflect.TypeOf(service).String()}
+	}
+	if err := instance.bootService(key, typed, instance.bootContext(key, binding.ctx)); err != nil {
+		return zero, instance.initializationError(key.typ, err)
+	}
+	return typed, nil
+}
+
+// copyUnbooted returns a shallow copy of the service bound for key. It holds
+// the key's boot lock while copying so the service cannot start booting
+// underneath it, and refuses a service that has booted, whose fields are live
+// and may be written by its owners concurrently.
+func copyUnbooted(instance *container, key bindingKey) (bindingDefinition, Lifecycle, error) {
+	lock := instance.initLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+	binding, ok := instance.getBinding(key)
+	if !ok {
+		return binding, nil, instance.missingBinding(key)
+	}
+	if binding.initialized {
+		return binding, nil, &NotCloneableError{Type: key.typ.String()}
+	}
+	service := binding.concrete
+	if binding.hasCondition() {
+		var err error
+		if service, err = binding.evaluate(); err != nil {
+			return binding, nil, err
+		}
+	}
+	if !isCloneable(service) {
+		return binding, nil, &NotCloneableError{Type: key.typ.String()}
+	}
+	return binding, cloneService(service), nil
+}
+
+// constructTransient builds a service with the binding's factory and boots it.
+func constructTransient[T Lifecycle](c *container, key bindingKey, binding bindingDefinition, bootCtx *ContainerContext, args []any) (T, error) {
+	var zero T
+	service, err := binding.factory(binding.ctx, args)
+	if err != nil {
+		return zero, c.initializationError(key.typ, err)
+	}
+	if isNilService(service) {
+		return zero, c.initializationError(key.typ, fmt.Errorf("factory returned nil"))
+	}
+	typed, ok := service.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Expected: key.typ.String(), Got: reflect.TypeOf(service).String()}
+	}
+	if err := c.bootService(key, typed, c.bootContext(key, bootCtx)); err != nil {
+		return zero, c.initializationError(key.typ, err)
+	}
+	return typed, nil
+}
+
+// isCloneable reports whether cloneService can copy the service.
+func isCloneable(service Lifecycle) bool {
+	v := reflect.ValueOf(service)
+	return v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct
+}
+
+// cloneService returns a shallow copy of a pointer-to-struct service.
+func cloneService(service Lifecycle) Lifecycle {
+	v := reflect.ValueOf(service)
+	clone := reflect.New(v.Elem().Type())
+	clone.Elem().Set(v.Elem())
+	return clone.Interface().(Lifecycle)
+}
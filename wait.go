@@ -0,0 +1,63 @@
+package digo
+
+import (
+	"context"
+	"reflect"
+)
+
+// ResolveSingletonWait resolves a singleton like ResolveSingleton, but if T is
+// not bound yet it waits for a later binding to register it, so services can
+// be resolved before the plugin providing them has loaded.
+// Returns TimeoutError wrapping ctx.Err() if ctx is done before T is bound.
+// Returns the errors of ResolveSingleton otherwise.
+func ResolveSingletonWait[T Lifecycle](ctx context.Context) (T, error) {
+	var zero T
+	instance := GetContainer()
+	key := makeBindingKey(ScopeSingleton, reflect.TypeOf((*T)(nil)).Elem())
+	for {
+		bound := instance.bindNotify(key)
+		if bound == nil {
+			return wrapResult(resolveSingleton[T](key))
+		}
+		select {
+		case <-bound:
+		case <-ctx.Done():
+			return zero, instance.wrapError(&TimeoutError{Type: key.typ.String(), Err: ctx.Err()})
+		}
+	}
+}
+
+// bindNotify returns a channel closed once key is bound, or nil if key, or a
+// parent's key it falls back to, is bound already.
+func (c *container) bindNotify(key bindingKey) <-chan struct{} {
+	if c.parentOwner(key) != nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	if _, ok := c.bindings.Get(key); ok {
+		return nil
+	}
+	if c.bindWaiters == nil {
+		c.bindWaiters = make(map[bindingKey]chan struct{})
+	}
+	bound, ok := c.bindWaiters[key]
+	if !ok {
+		bound = make(chan struct{})
+		c.bindWaiters[key] = bound
+	}
+	return bound
+}
+
+// notifyBound wakes the callers waiting for key to be bound.
+// Callers must hold c.mu, shared or exclusive.
+func (c *container) notifyBound(key bindingKey) {
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	if bound, ok := c.bindWaiters[key]; ok {
+		close(bound)
+		delete(c.bindWaiters, key)
+	}
+}